@@ -0,0 +1,58 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package animation provides encoders for animated GIF and APNG images
+// built from a sequence of rendered frames.
+package animation
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+	"time"
+)
+
+// Animation accumulates frames for encoding as an animated image.
+// The zero value is an empty animation that loops forever with no
+// delay between frames.
+type Animation struct {
+	// Delay is the display time of each frame.
+	// It must not be negative.
+	Delay time.Duration
+
+	// LoopCount controls the number of times the animation is
+	// restarted during display. A LoopCount of 0 loops forever,
+	// a LoopCount of -1 shows each frame once, and otherwise the
+	// animation is shown LoopCount+1 times. This follows the
+	// convention of image/gif.
+	LoopCount int
+
+	frames []*image.NRGBA
+}
+
+// ErrSize is returned by Add when a frame's size does not match the
+// size of the first frame of the animation.
+var ErrSize = errors.New("animation: frame size mismatch")
+
+// Add appends a copy of img to the animation. The copy is translated so
+// that its bounds start at the origin, so renderers may reuse img after
+// Add returns. All frames must have the same size.
+func (a *Animation) Add(img image.Image) error {
+	b := img.Bounds()
+	if len(a.frames) != 0 && a.frames[0].Bounds().Size() != b.Size() {
+		return ErrSize
+	}
+	dst := image.NewNRGBA(image.Rectangle{Max: b.Size()})
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+	a.frames = append(a.frames, dst)
+	return nil
+}
+
+// Len returns the number of frames in the animation.
+func (a *Animation) Len() int { return len(a.frames) }
+
+// Reset removes all frames from the animation.
+func (a *Animation) Reset() {
+	a.frames = a.frames[:0]
+}
@@ -0,0 +1,238 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package animation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func testFrames() []image.Image {
+	var frames []image.Image
+	for i, c := range []color.Color{
+		color.NRGBA{R: 0xff, A: 0xff},
+		color.NRGBA{G: 0xff, A: 0xff},
+		color.NRGBA{B: 0xff, A: 0x80},
+	} {
+		// Offset bounds to check frames are translated to the origin.
+		img := image.NewNRGBA(image.Rect(i, i, 4+i, 3+i))
+		for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+			for x := img.Rect.Min.X; x < img.Rect.Max.X; x++ {
+				img.Set(x, y, c)
+			}
+		}
+		img.Set(img.Rect.Min.X, img.Rect.Min.Y, color.NRGBA{A: 0xff})
+		frames = append(frames, img)
+	}
+	return frames
+}
+
+func TestAdd(t *testing.T) {
+	var a Animation
+	for _, f := range testFrames() {
+		err := a.Add(f)
+		if err != nil {
+			t.Fatalf("unexpected error adding frame: %v", err)
+		}
+	}
+	if a.Len() != 3 {
+		t.Errorf("unexpected number of frames: got:%d want:3", a.Len())
+	}
+	err := a.Add(image.NewNRGBA(image.Rect(0, 0, 3, 4)))
+	if err != ErrSize {
+		t.Errorf("unexpected error for mismatched frame: got:%v want:%v", err, ErrSize)
+	}
+	a.Reset()
+	if a.Len() != 0 {
+		t.Errorf("unexpected number of frames after reset: got:%d want:0", a.Len())
+	}
+}
+
+func TestEncodeGIF(t *testing.T) {
+	a := Animation{Delay: 104 * time.Millisecond, LoopCount: 2}
+	frames := testFrames()
+	for _, f := range frames {
+		a.Add(f)
+	}
+	var buf bytes.Buffer
+	err := a.EncodeGIF(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error encoding GIF: %v", err)
+	}
+	g, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error decoding GIF: %v", err)
+	}
+	if len(g.Image) != len(frames) {
+		t.Fatalf("unexpected number of frames: got:%d want:%d", len(g.Image), len(frames))
+	}
+	if g.LoopCount != a.LoopCount {
+		t.Errorf("unexpected loop count: got:%d want:%d", g.LoopCount, a.LoopCount)
+	}
+	for i, d := range g.Delay {
+		if d != 10 {
+			t.Errorf("unexpected delay for frame %d: got:%d want:10", i, d)
+		}
+	}
+	for i, f := range g.Image {
+		if f.Bounds() != image.Rect(0, 0, 4, 3) {
+			t.Errorf("unexpected bounds for frame %d: got:%v", i, f.Bounds())
+		}
+		r, _, _, _ := f.At(0, 0).RGBA()
+		if r != 0 {
+			t.Errorf("unexpected corner color for frame %d: got:%v", i, f.At(0, 0))
+		}
+	}
+
+	err = new(Animation).EncodeGIF(&buf)
+	if err == nil {
+		t.Error("expected error for empty animation")
+	}
+}
+
+func TestEncodeDelayRounding(t *testing.T) {
+	for _, test := range []struct {
+		delay time.Duration
+		gif   int
+		apng  uint16
+	}{
+		{delay: 0, gif: 0, apng: 0},
+		{delay: 400 * time.Microsecond, gif: 0, apng: 0},
+		{delay: 1600 * time.Microsecond, gif: 0, apng: 2},
+		{delay: 4 * time.Millisecond, gif: 0, apng: 4},
+		{delay: 16 * time.Millisecond, gif: 2, apng: 16},
+		{delay: 104*time.Millisecond + 600*time.Microsecond, gif: 10, apng: 105},
+	} {
+		a := Animation{Delay: test.delay}
+		a.Add(testFrames()[0])
+
+		var buf bytes.Buffer
+		err := a.EncodeGIF(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error encoding GIF: %v", err)
+		}
+		g, err := gif.DecodeAll(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error decoding GIF: %v", err)
+		}
+		if g.Delay[0] != test.gif {
+			t.Errorf("unexpected GIF delay for %v: got:%d want:%d", test.delay, g.Delay[0], test.gif)
+		}
+
+		buf.Reset()
+		err = a.EncodeAPNG(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error encoding APNG: %v", err)
+		}
+		i := bytes.Index(buf.Bytes(), []byte("fcTL"))
+		if i < 0 {
+			t.Fatalf("missing fcTL chunk for %v", test.delay)
+		}
+		if got := binary.BigEndian.Uint16(buf.Bytes()[i+4+20:]); got != test.apng {
+			t.Errorf("unexpected APNG delay for %v: got:%d want:%d", test.delay, got, test.apng)
+		}
+	}
+}
+
+func TestEncodeNegativeDelay(t *testing.T) {
+	a := Animation{Delay: -time.Millisecond}
+	for _, f := range testFrames() {
+		a.Add(f)
+	}
+	var buf bytes.Buffer
+	if err := a.EncodeGIF(&buf); err == nil {
+		t.Error("expected error for negative GIF delay")
+	}
+	if err := a.EncodeAPNG(&buf); err == nil {
+		t.Error("expected error for negative APNG delay")
+	}
+}
+
+func TestEncodeAPNG(t *testing.T) {
+	a := Animation{Delay: 40 * time.Millisecond, LoopCount: -1}
+	frames := testFrames()
+	for _, f := range frames {
+		a.Add(f)
+	}
+	var buf bytes.Buffer
+	err := a.EncodeAPNG(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error encoding APNG: %v", err)
+	}
+
+	// Decoders without APNG support see the first frame.
+	img, err := png.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("unexpected error decoding PNG: %v", err)
+	}
+	want := frames[0].(*image.NRGBA)
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			got := color.NRGBAModel.Convert(img.At(x, y))
+			if w := want.At(x+want.Rect.Min.X, y+want.Rect.Min.Y); got != w {
+				t.Errorf("unexpected color at (%d,%d): got:%v want:%v", x, y, got, w)
+			}
+		}
+	}
+
+	// Check the animation control chunks.
+	data := buf.Bytes()[len(pngHeader):]
+	var (
+		types []string
+		seq   []uint32
+		plays uint32
+	)
+	for len(data) != 0 {
+		n := binary.BigEndian.Uint32(data)
+		typ := string(data[4:8])
+		body := data[8 : 8+n]
+		crc := binary.BigEndian.Uint32(data[8+n:])
+		if got := crc32.ChecksumIEEE(data[4 : 8+n]); got != crc {
+			t.Errorf("unexpected checksum for %s chunk: got:%#x want:%#x", typ, got, crc)
+		}
+		types = append(types, typ)
+		switch typ {
+		case "acTL":
+			if frames := binary.BigEndian.Uint32(body); frames != 3 {
+				t.Errorf("unexpected number of frames: got:%d want:3", frames)
+			}
+			plays = binary.BigEndian.Uint32(body[4:])
+		case "fcTL":
+			seq = append(seq, binary.BigEndian.Uint32(body))
+			num, den := binary.BigEndian.Uint16(body[20:]), binary.BigEndian.Uint16(body[22:])
+			if num != 40 || den != 1000 {
+				t.Errorf("unexpected frame delay: got:%d/%d want:40/1000", num, den)
+			}
+		case "fdAT":
+			seq = append(seq, binary.BigEndian.Uint32(body))
+		}
+		data = data[12+n:]
+	}
+	wantTypes := []string{"IHDR", "acTL", "fcTL", "IDAT", "fcTL", "fdAT", "fcTL", "fdAT", "IEND"}
+	if len(types) != len(wantTypes) {
+		t.Fatalf("unexpected chunks: got:%v want:%v", types, wantTypes)
+	}
+	for i := range types {
+		if types[i] != wantTypes[i] {
+			t.Fatalf("unexpected chunks: got:%v want:%v", types, wantTypes)
+		}
+	}
+	for i, s := range seq {
+		if s != uint32(i) {
+			t.Errorf("unexpected sequence numbers: got:%v", seq)
+			break
+		}
+	}
+	if plays != 1 {
+		t.Errorf("unexpected number of plays: got:%d want:1", plays)
+	}
+}
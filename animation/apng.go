@@ -0,0 +1,144 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package animation
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"time"
+)
+
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+// APNG blending and disposal operations.
+const (
+	apngDisposeNone = 0
+	apngBlendSource = 0
+)
+
+// EncodeAPNG writes the animation to w as an animated PNG. Frames are
+// stored as 8-bit non-alpha-premultiplied RGBA without loss. Decoders
+// that do not support APNG display the first frame.
+//
+// The frame delay is rounded to the nearest millisecond and is limited
+// to 65.535s.
+func (a *Animation) EncodeAPNG(w io.Writer) error {
+	if len(a.frames) == 0 {
+		return errors.New("animation: no frames")
+	}
+	if a.Delay < 0 {
+		return errors.New("animation: negative delay")
+	}
+	delay := (a.Delay + time.Millisecond/2) / time.Millisecond
+	if delay > 0xffff {
+		return errors.New("animation: delay too long")
+	}
+	var plays uint32
+	switch {
+	case a.LoopCount < 0:
+		plays = 1
+	case a.LoopCount > 0:
+		plays = uint32(a.LoopCount) + 1
+	}
+
+	e := apngEncoder{w: bufio.NewWriter(w)}
+	e.w.WriteString(pngHeader)
+
+	size := a.frames[0].Bounds().Size()
+	var ihdr [13]byte
+	binary.BigEndian.PutUint32(ihdr[0:], uint32(size.X))
+	binary.BigEndian.PutUint32(ihdr[4:], uint32(size.Y))
+	ihdr[8] = 8  // Bit depth.
+	ihdr[9] = 6  // Color type: truecolor with alpha.
+	ihdr[10] = 0 // Compression method.
+	ihdr[11] = 0 // Filter method.
+	ihdr[12] = 0 // Interlace method: none.
+	e.writeChunk("IHDR", ihdr[:])
+
+	var actl [8]byte
+	binary.BigEndian.PutUint32(actl[0:], uint32(len(a.frames)))
+	binary.BigEndian.PutUint32(actl[4:], plays)
+	e.writeChunk("acTL", actl[:])
+
+	var (
+		seq  uint32
+		data bytes.Buffer
+	)
+	for i, f := range a.frames {
+		var fctl [26]byte
+		binary.BigEndian.PutUint32(fctl[0:], seq)
+		binary.BigEndian.PutUint32(fctl[4:], uint32(size.X))
+		binary.BigEndian.PutUint32(fctl[8:], uint32(size.Y))
+		// The x and y offsets at fctl[12:20] are zero.
+		binary.BigEndian.PutUint16(fctl[20:], uint16(delay))
+		binary.BigEndian.PutUint16(fctl[22:], 1000)
+		fctl[24] = apngDisposeNone
+		fctl[25] = apngBlendSource
+		e.writeChunk("fcTL", fctl[:])
+		seq++
+
+		data.Reset()
+		if i != 0 {
+			var s [4]byte
+			binary.BigEndian.PutUint32(s[:], seq)
+			data.Write(s[:])
+			seq++
+		}
+		z := zlib.NewWriter(&data)
+		for y := 0; y < size.Y; y++ {
+			// Each scanline is preceded by its filter type;
+			// no filtering is applied.
+			z.Write([]byte{0})
+			z.Write(f.Pix[y*f.Stride : y*f.Stride+4*size.X])
+		}
+		err := z.Close()
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			e.writeChunk("IDAT", data.Bytes())
+		} else {
+			e.writeChunk("fdAT", data.Bytes())
+		}
+	}
+	e.writeChunk("IEND", nil)
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+// apngEncoder writes PNG chunks, retaining the first write error.
+type apngEncoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (e *apngEncoder) writeChunk(typ string, data []byte) {
+	if e.err != nil {
+		return
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint32(buf[:4], uint32(len(data)))
+	copy(buf[4:], typ)
+	crc := crc32.NewIEEE()
+	crc.Write(buf[4:])
+	crc.Write(data)
+	_, e.err = e.w.Write(buf[:])
+	if e.err != nil {
+		return
+	}
+	_, e.err = e.w.Write(data)
+	if e.err != nil {
+		return
+	}
+	binary.BigEndian.PutUint32(buf[:4], crc.Sum32())
+	_, e.err = e.w.Write(buf[:4])
+}
@@ -0,0 +1,40 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package animation
+
+import (
+	"errors"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+	"time"
+)
+
+// EncodeGIF writes the animation to w as an animated GIF. Frames are
+// quantized to the Plan 9 palette using Floyd-Steinberg dithering, and
+// the frame delay is rounded to the nearest hundredth of a second.
+func (a *Animation) EncodeGIF(w io.Writer) error {
+	if len(a.frames) == 0 {
+		return errors.New("animation: no frames")
+	}
+	if a.Delay < 0 {
+		return errors.New("animation: negative delay")
+	}
+	delay := int((a.Delay + 5*time.Millisecond) / (10 * time.Millisecond))
+	g := gif.GIF{
+		Image:     make([]*image.Paletted, len(a.frames)),
+		Delay:     make([]int, len(a.frames)),
+		LoopCount: a.LoopCount,
+	}
+	for i, f := range a.frames {
+		p := image.NewPaletted(f.Bounds(), palette.Plan9)
+		draw.FloydSteinberg.Draw(p, p.Bounds(), f, image.Point{})
+		g.Image[i] = p
+		g.Delay[i] = delay
+	}
+	return gif.EncodeAll(w, &g)
+}
@@ -0,0 +1,214 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package colormap provides sequential and diverging color maps for
+// mapping scalar attributes to colors.
+//
+// The sequential maps are derived from the maps designed by Stéfan van
+// der Walt and Nathaniel Smith for matplotlib, and the diverging maps
+// are built from the ColorBrewer palettes by Cynthia Brewer provided by
+// gonum.org/v1/plot/palette/brewer. Each map is represented by a set of
+// evenly spaced control colors that are linearly interpolated in sRGB
+// space. The sequential maps sample the matplotlib maps at ten control
+// colors, so they follow the originals closely but do not retain their
+// exact perceptual uniformity.
+package colormap
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/palette/brewer"
+)
+
+// ramp is a palette.ColorMap that linearly interpolates between
+// evenly spaced control colors.
+type ramp struct {
+	controls []color.NRGBA
+
+	min, max float64
+	alpha    float64
+}
+
+func newRamp(hex ...uint32) *ramp {
+	controls := make([]color.NRGBA, len(hex))
+	for i, h := range hex {
+		controls[i] = color.NRGBA{R: uint8(h >> 16), G: uint8(h >> 8), B: uint8(h), A: 0xff}
+	}
+	return &ramp{controls: controls, max: 1, alpha: 1}
+}
+
+// brewerRamp returns a ramp through the colors of the named
+// ColorBrewer diverging palette with n colors.
+func brewerRamp(name string, n int) *ramp {
+	p, err := brewer.GetPalette(brewer.TypeDiverging, name, n)
+	if err != nil {
+		panic(err)
+	}
+	colors := p.Colors()
+	controls := make([]color.NRGBA, len(colors))
+	for i, c := range colors {
+		controls[i] = color.NRGBAModel.Convert(c).(color.NRGBA)
+	}
+	return &ramp{controls: controls, max: 1, alpha: 1}
+}
+
+// At implements the palette.ColorMap interface.
+func (r *ramp) At(v float64) (color.Color, error) {
+	if r.max <= r.min {
+		panic("colormap: max not greater than min")
+	}
+	switch {
+	case math.IsNaN(v):
+		return nil, palette.ErrNaN
+	case v > r.max:
+		return nil, palette.ErrOverflow
+	case v < r.min:
+		return nil, palette.ErrUnderflow
+	}
+	return r.at((v - r.min) / (r.max - r.min)), nil
+}
+
+// at returns the color at the normalized position t in [0, 1].
+func (r *ramp) at(t float64) color.Color {
+	pos := t * float64(len(r.controls)-1)
+	i := int(pos)
+	if i >= len(r.controls)-1 {
+		i = len(r.controls) - 2
+	}
+	frac := pos - float64(i)
+	lo, hi := r.controls[i], r.controls[i+1]
+	return color.NRGBA{
+		R: lerp(lo.R, hi.R, frac),
+		G: lerp(lo.G, hi.G, frac),
+		B: lerp(lo.B, hi.B, frac),
+		A: uint8(math.Round(r.alpha * 0xff)),
+	}
+}
+
+func lerp(a, b uint8, t float64) uint8 {
+	return uint8(math.Round(float64(a) + t*(float64(b)-float64(a))))
+}
+
+// Max implements the palette.ColorMap interface.
+func (r *ramp) Max() float64 { return r.max }
+
+// SetMax implements the palette.ColorMap interface.
+func (r *ramp) SetMax(v float64) { r.max = v }
+
+// Min implements the palette.ColorMap interface.
+func (r *ramp) Min() float64 { return r.min }
+
+// SetMin implements the palette.ColorMap interface.
+func (r *ramp) SetMin(v float64) { r.min = v }
+
+// Alpha implements the palette.ColorMap interface.
+func (r *ramp) Alpha() float64 { return r.alpha }
+
+// SetAlpha implements the palette.ColorMap interface.
+func (r *ramp) SetAlpha(alpha float64) {
+	if alpha < 0 || alpha > 1 {
+		panic("colormap: invalid alpha")
+	}
+	r.alpha = alpha
+}
+
+// Palette implements the palette.ColorMap interface. The returned
+// palette holds n colors evenly spaced between the minimum and the
+// maximum of the color map.
+func (r *ramp) Palette(n int) palette.Palette {
+	if n < 1 {
+		panic("colormap: invalid number of colors")
+	}
+	if r.max <= r.min {
+		panic("colormap: max not greater than min")
+	}
+	p := make(colors, n)
+	if n == 1 {
+		p[0] = r.at(0)
+		return p
+	}
+	for i := range p {
+		p[i] = r.at(float64(i) / float64(n-1))
+	}
+	return p
+}
+
+// colors implements the palette.Palette interface.
+type colors []color.Color
+
+func (p colors) Colors() []color.Color { return p }
+
+// Colors returns the colors in cm for each of the values. Values outside
+// the range of cm are clamped to its minimum or maximum. If any value is
+// NaN, Colors returns a nil slice and palette.ErrNaN.
+func Colors(cm palette.ColorMap, values []float64) ([]color.Color, error) {
+	dst := make([]color.Color, len(values))
+	min, max := cm.Min(), cm.Max()
+	for i, v := range values {
+		var err error
+		dst[i], err = cm.At(math.Max(min, math.Min(v, max)))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dst, nil
+}
+
+// Viridis returns the matplotlib viridis sequential color map ranging
+// from dark blue through green to yellow. The returned map has a range
+// of [0, 1] and is opaque.
+func Viridis() palette.ColorMap {
+	return newRamp(
+		0x440154, 0x482878, 0x3e4a89, 0x31688e, 0x26828e,
+		0x1f9e89, 0x35b779, 0x6dcd59, 0xb4de2c, 0xfde725,
+	)
+}
+
+// Inferno returns the matplotlib inferno sequential color map ranging
+// from black through red to pale yellow. The returned map has a range
+// of [0, 1] and is opaque.
+func Inferno() palette.ColorMap {
+	return newRamp(
+		0x000004, 0x1b0c42, 0x4b0c6b, 0x781c6d, 0xa52c60,
+		0xcf4446, 0xed6925, 0xfb9a06, 0xf7d03c, 0xfcffa4,
+	)
+}
+
+// Magma returns the matplotlib magma sequential color map ranging
+// from black through purple to pale yellow. The returned map has a
+// range of [0, 1] and is opaque.
+func Magma() palette.ColorMap {
+	return newRamp(
+		0x000004, 0x180f3e, 0x451077, 0x721f81, 0x9f2f7f,
+		0xcd4071, 0xf1605d, 0xfd9567, 0xfec98d, 0xfcfdbf,
+	)
+}
+
+// Plasma returns the matplotlib plasma sequential color map ranging
+// from dark blue through magenta to yellow. The returned map has a
+// range of [0, 1] and is opaque.
+func Plasma() palette.ColorMap {
+	return newRamp(
+		0x0d0887, 0x47039f, 0x7301a8, 0x9c179e, 0xbd3786,
+		0xd8576b, 0xed7953, 0xfa9e3b, 0xfdc926, 0xf0f921,
+	)
+}
+
+// RedBlue returns the ColorBrewer RdBu diverging color map ranging
+// from dark red through white to dark blue. The returned map has a
+// range of [0, 1] and is opaque; the neutral color is at the midpoint
+// of the range.
+func RedBlue() palette.ColorMap {
+	return brewerRamp("RdBu", 11)
+}
+
+// PurpleOrange returns the ColorBrewer PuOr diverging color map ranging
+// from dark orange through white to dark purple. The returned map has
+// a range of [0, 1] and is opaque; the neutral color is at the midpoint
+// of the range.
+func PurpleOrange() palette.ColorMap {
+	return brewerRamp("PuOr", 11)
+}
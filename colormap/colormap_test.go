@@ -0,0 +1,157 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot/palette"
+)
+
+var colorMaps = []struct {
+	name string
+	new  func() palette.ColorMap
+}{
+	{name: "Viridis", new: Viridis},
+	{name: "Inferno", new: Inferno},
+	{name: "Magma", new: Magma},
+	{name: "Plasma", new: Plasma},
+	{name: "RedBlue", new: RedBlue},
+	{name: "PurpleOrange", new: PurpleOrange},
+}
+
+func TestColorMapEnds(t *testing.T) {
+	for _, test := range colorMaps {
+		cm := test.new()
+		r := cm.(*ramp)
+		cm.SetMin(-2)
+		cm.SetMax(6)
+		for _, end := range []struct {
+			v    float64
+			want color.NRGBA
+		}{
+			{v: -2, want: r.controls[0]},
+			{v: 6, want: r.controls[len(r.controls)-1]},
+		} {
+			got, err := cm.At(end.v)
+			if err != nil {
+				t.Errorf("unexpected error for %s at %v: %v", test.name, end.v, err)
+				continue
+			}
+			if got != end.want {
+				t.Errorf("unexpected color for %s at %v: got:%v want:%v", test.name, end.v, got, end.want)
+			}
+		}
+	}
+}
+
+func TestDivergingEnds(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		new       func() palette.ColorMap
+		low, high color.NRGBA
+	}{
+		{
+			name: "RedBlue", new: RedBlue,
+			low:  color.NRGBA{R: 0x67, G: 0x00, B: 0x1f, A: 0xff},
+			high: color.NRGBA{R: 0x05, G: 0x30, B: 0x61, A: 0xff},
+		},
+		{
+			name: "PurpleOrange", new: PurpleOrange,
+			low:  color.NRGBA{R: 0x7f, G: 0x3b, B: 0x08, A: 0xff},
+			high: color.NRGBA{R: 0x2d, G: 0x00, B: 0x4b, A: 0xff},
+		},
+	} {
+		cm := test.new()
+		for _, end := range []struct {
+			v    float64
+			want color.NRGBA
+		}{
+			{v: 0, want: test.low},
+			{v: 0.5, want: color.NRGBA{R: 0xf7, G: 0xf7, B: 0xf7, A: 0xff}},
+			{v: 1, want: test.high},
+		} {
+			got, _ := cm.At(end.v)
+			if got != end.want {
+				t.Errorf("unexpected color for %s at %v: got:%v want:%v", test.name, end.v, got, end.want)
+			}
+		}
+	}
+}
+
+func TestColorMapErrors(t *testing.T) {
+	for _, test := range colorMaps {
+		cm := test.new()
+		for _, v := range []struct {
+			v    float64
+			want error
+		}{
+			{v: math.NaN(), want: palette.ErrNaN},
+			{v: -0.1, want: palette.ErrUnderflow},
+			{v: 1.1, want: palette.ErrOverflow},
+		} {
+			_, err := cm.At(v.v)
+			if err != v.want {
+				t.Errorf("unexpected error for %s at %v: got:%v want:%v", test.name, v.v, err, v.want)
+			}
+		}
+	}
+}
+
+func TestColorMapInterpolation(t *testing.T) {
+	cm := newRamp(0x000000, 0xff8040)
+	cm.SetAlpha(0.5)
+	got, err := cm.At(0.5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := color.NRGBA{R: 0x80, G: 0x40, B: 0x20, A: 0x80}
+	if got != want {
+		t.Errorf("unexpected color: got:%v want:%v", got, want)
+	}
+}
+
+func TestPalette(t *testing.T) {
+	for _, test := range colorMaps {
+		cm := test.new()
+		r := cm.(*ramp)
+		for _, n := range []int{1, 2, 7, 256} {
+			p := cm.Palette(n).Colors()
+			if len(p) != n {
+				t.Errorf("unexpected palette length for %s: got:%d want:%d", test.name, len(p), n)
+				continue
+			}
+			if p[0] != r.controls[0] {
+				t.Errorf("unexpected first palette color for %s: got:%v want:%v", test.name, p[0], r.controls[0])
+			}
+			if n > 1 && p[n-1] != r.controls[len(r.controls)-1] {
+				t.Errorf("unexpected last palette color for %s: got:%v want:%v", test.name, p[n-1], r.controls[len(r.controls)-1])
+			}
+		}
+	}
+}
+
+func TestColors(t *testing.T) {
+	cm := Viridis()
+	cm.SetMin(10)
+	cm.SetMax(20)
+	got, err := Colors(cm, []float64{0, 10, 15, 20, 30})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, v := range []float64{10, 10, 15, 20, 20} {
+		want, _ := cm.At(v)
+		if got[i] != want {
+			t.Errorf("unexpected color for value %d: got:%v want:%v", i, got[i], want)
+		}
+	}
+
+	_, err = Colors(cm, []float64{15, math.NaN()})
+	if err != palette.ErrNaN {
+		t.Errorf("unexpected error for NaN value: got:%v want:%v", err, palette.ErrNaN)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package golden provides tolerance-aware comparisons of layout and
+// rendering output against reference results, for writing tests that
+// are robust to insignificant numerical and rendering differences.
+package golden
+
+import (
+	"math"
+
+	"gonum.org/v1/exp/graph/layout"
+	"gonum.org/v1/exp/spatial/r2"
+)
+
+// Point is a location in the plane.
+type Point = r2.Vec
+
+// ProcrustesRMSD returns the root mean square distance between the
+// corresponding points of got and want after got has been optimally
+// translated, rotated and reflected, and if scale is true uniformly
+// scaled, to match want. Layouts are usually only defined up to these
+// transformations, so the distance measures differences in shape alone.
+//
+// ProcrustesRMSD will panic if got and want have different lengths.
+//
+// References:
+//   - Gower, J. C. (1975). Generalized Procrustes analysis.
+//     Psychometrika, 40(1), 33-51.
+func ProcrustesRMSD(got, want []Point, scale bool) float64 {
+	if len(got) != len(want) {
+		panic("golden: length mismatch")
+	}
+	n := len(got)
+	if n == 0 {
+		return 0
+	}
+	a := centered(got)
+	b := centered(want)
+
+	// Find the rotation of a, or of its reflection, that
+	// maximizes the inner product with b.
+	var ssA, c, s, cr, sr float64
+	for i := range a {
+		ssA += a[i].X*a[i].X + a[i].Y*a[i].Y
+		c += a[i].X*b[i].X + a[i].Y*b[i].Y
+		s += a[i].X*b[i].Y - a[i].Y*b[i].X
+		cr += a[i].X*b[i].X - a[i].Y*b[i].Y
+		sr += a[i].X*b[i].Y + a[i].Y*b[i].X
+	}
+	reflect := math.Hypot(cr, sr) > math.Hypot(c, s)
+	if reflect {
+		c, s = cr, sr
+	}
+	dot := math.Hypot(c, s)
+	cos, sin := 1.0, 0.0
+	if dot != 0 {
+		cos, sin = c/dot, s/dot
+	}
+	k := 1.0
+	if scale {
+		k = 0
+		if ssA != 0 {
+			k = dot / ssA
+		}
+	}
+
+	// Sum the residuals directly rather than from the inner
+	// products to avoid cancellation for close matches.
+	var ss float64
+	for i, v := range a {
+		if reflect {
+			v.Y = -v.Y
+		}
+		dx := k*(cos*v.X-sin*v.Y) - b[i].X
+		dy := k*(sin*v.X+cos*v.Y) - b[i].Y
+		ss += dx*dx + dy*dy
+	}
+	return math.Sqrt(ss / float64(n))
+}
+
+// LayoutRMSD returns the ProcrustesRMSD between the positions of the
+// nodes in got and want.
+//
+// LayoutRMSD will panic if got and want do not hold the same nodes.
+func LayoutRMSD(got, want layout.Layout, scale bool) float64 {
+	if len(got) != len(want) {
+		panic("golden: layout node mismatch")
+	}
+	a := make([]Point, 0, len(got))
+	b := make([]Point, 0, len(want))
+	for id, p := range got {
+		q, ok := want[id]
+		if !ok {
+			panic("golden: layout node mismatch")
+		}
+		a = append(a, p)
+		b = append(b, q)
+	}
+	return ProcrustesRMSD(a, b, scale)
+}
+
+// centered returns a copy of p translated so that its centroid is the
+// origin.
+func centered(p []Point) []Point {
+	var mx, my float64
+	for _, v := range p {
+		mx += v.X
+		my += v.Y
+	}
+	mx /= float64(len(p))
+	my /= float64(len(p))
+	c := make([]Point, len(p))
+	for i, v := range p {
+		c[i] = Point{X: v.X - mx, Y: v.Y - my}
+	}
+	return c
+}
@@ -0,0 +1,126 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golden
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/exp/graph/layout"
+)
+
+func TestProcrustesRMSD(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	want := make([]Point, 20)
+	for i := range want {
+		want[i] = Point{X: rnd.NormFloat64(), Y: rnd.NormFloat64()}
+	}
+	transform := func(s, theta float64, reflect bool) []Point {
+		sin, cos := math.Sincos(theta)
+		p := make([]Point, len(want))
+		for i, v := range want {
+			if reflect {
+				v.Y = -v.Y
+			}
+			p[i] = Point{X: 3 + s*(cos*v.X-sin*v.Y), Y: -2 + s*(sin*v.X+cos*v.Y)}
+		}
+		return p
+	}
+	for _, test := range []struct {
+		got   []Point
+		scale bool
+		want  float64
+	}{
+		{got: want, want: 0},
+		{got: transform(1, 1, false), want: 0},
+		{got: transform(1, 2, true), want: 0},
+		{got: transform(2, -1, false), scale: true, want: 0},
+		{got: transform(0.5, 3, true), scale: true, want: 0},
+	} {
+		if got := ProcrustesRMSD(test.got, want, test.scale); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected RMSD: got:%v want:%v", got, test.want)
+		}
+	}
+
+	// Scaling is not removed unless requested. The residual
+	// of an unscaled copy is the spread of the smaller set.
+	got := ProcrustesRMSD(transform(2, 1, false), want, false)
+	spread := math.Sqrt(sumSquares(centered(want)) / float64(len(want)))
+	if math.Abs(got-spread) > 1e-12 {
+		t.Errorf("unexpected RMSD for unscaled comparison: got:%v want:%v", got, spread)
+	}
+
+	// A single displaced point.
+	moved := append([]Point(nil), want...)
+	moved[0].X += 1
+	if got := ProcrustesRMSD(moved, want, false); got <= 0 || got >= 1/math.Sqrt(float64(len(want))) {
+		t.Errorf("unexpected RMSD for displaced point: got:%v", got)
+	}
+
+	l := layout.Layout{1: {X: 0, Y: 0}, 2: {X: 1, Y: 0}, 3: {X: 0, Y: 2}}
+	r := layout.Layout{1: {X: 5, Y: 5}, 2: {X: 5, Y: 6}, 3: {X: 3, Y: 5}}
+	if got := LayoutRMSD(l, r, false); got > 1e-12 {
+		t.Errorf("unexpected layout RMSD: got:%v want:0", got)
+	}
+}
+
+func sumSquares(p []Point) float64 {
+	var s float64
+	for _, v := range p {
+		s += v.X*v.X + v.Y*v.Y
+	}
+	return s
+}
+
+func TestImageDiff(t *testing.T) {
+	a := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	b := image.NewNRGBA(image.Rect(5, 5, 15, 15))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			a.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+			b.Set(x+5, y+5, color.NRGBA{R: 201, G: 100, B: 50, A: 255})
+		}
+	}
+	b.Set(5, 5, color.NRGBA{R: 0, G: 0, B: 255, A: 255})
+	// A transparent pixel matches white.
+	a.Set(9, 9, color.RGBA{})
+	b.Set(14, 14, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+
+	got, err := ImageDiff(a, b, 2.3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 0.01 {
+		t.Errorf("unexpected difference: got:%v want:0.01", got)
+	}
+
+	_, err = ImageDiff(a, image.NewRGBA(image.Rect(0, 0, 10, 11)), 2.3)
+	if err != ErrSize {
+		t.Errorf("unexpected error for size mismatch: got:%v want:%v", err, ErrSize)
+	}
+}
+
+func TestSVGEqual(t *testing.T) {
+	const want = `<svg width="100" height="50"><path d="M1.5,2 L3.25,-4e-1"/></svg>`
+	for _, test := range []struct {
+		got  string
+		tol  float64
+		want bool
+	}{
+		{got: want, want: true},
+		{got: `<svg width="100" height="50"><path d="M1.5001,2 L3.2499,-0.4"/></svg>`, tol: 1e-3, want: true},
+		{got: `<svg width="100" height="50"><path d="M1.5001,2 L3.2499,-0.4"/></svg>`, tol: 1e-5, want: false},
+		{got: `<svg width="100" height="50"><path d="M1.5,2 l3.25,-0.4"/></svg>`, tol: 1, want: false},
+		{got: `<svg width="100" height="50"><path d="M1.5,2 L3.25,-0.4,0"/></svg>`, tol: 1, want: false},
+	} {
+		if got := SVGEqual([]byte(test.got), []byte(want), test.tol); got != test.want {
+			t.Errorf("unexpected result for %q with tolerance %v: got:%t want:%t", test.got, test.tol, got, test.want)
+		}
+	}
+}
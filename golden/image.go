@@ -0,0 +1,106 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golden
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+// ErrSize is returned by ImageDiff when the images have different sizes.
+var ErrSize = errors.New("golden: image size mismatch")
+
+// ImageDiff returns the fraction of pixels of got and want whose colors
+// differ by more than threshold, measured as the CIE76 color difference
+// ΔE*ab in the CIELAB color space, where a difference of about 2.3 is just
+// noticeable. Pixels are composited over white before comparison, and are
+// compared relative to the minimum points of the image bounds.
+func ImageDiff(got, want image.Image, threshold float64) (float64, error) {
+	gb, wb := got.Bounds(), want.Bounds()
+	if gb.Dx() != wb.Dx() || gb.Dy() != wb.Dy() {
+		return 0, ErrSize
+	}
+	if gb.Empty() {
+		return 0, nil
+	}
+	var n int
+	for y := 0; y < gb.Dy(); y++ {
+		for x := 0; x < gb.Dx(); x++ {
+			l0, a0, b0 := lab(got.At(gb.Min.X+x, gb.Min.Y+y))
+			l1, a1, b1 := lab(want.At(wb.Min.X+x, wb.Min.Y+y))
+			dl, da, db := l0-l1, a0-a1, b0-b1
+			if math.Sqrt(dl*dl+da*da+db*db) > threshold {
+				n++
+			}
+		}
+	}
+	return float64(n) / float64(gb.Dx()*gb.Dy()), nil
+}
+
+// lab returns the CIELAB coordinates of c composited over white, under
+// the D65 illuminant.
+func lab(c color.Color) (l, a, b float64) {
+	r, g, bl, al := c.RGBA()
+	// Composite over white in premultiplied sRGB.
+	white := float64(0xffff - al)
+	lin := func(v uint32) float64 {
+		u := (float64(v) + white) / 0xffff
+		if u <= 0.04045 {
+			return u / 12.92
+		}
+		return math.Pow((u+0.055)/1.055, 2.4)
+	}
+	rl, gl, bll := lin(r), lin(g), lin(bl)
+	x := (0.4124*rl + 0.3576*gl + 0.1805*bll) / 0.95047
+	y := 0.2126*rl + 0.7152*gl + 0.0722*bll
+	z := (0.0193*rl + 0.1192*gl + 0.9505*bll) / 1.08883
+	f := func(t float64) float64 {
+		const d = 6.0 / 29
+		if t > d*d*d {
+			return math.Cbrt(t)
+		}
+		return t/(3*d*d) + 4.0/29
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return 116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)
+}
+
+// number matches decimal numbers in text.
+var number = regexp.MustCompile(`[-+]?(?:[0-9]+\.?[0-9]*|\.[0-9]+)(?:[eE][-+]?[0-9]+)?`)
+
+// SVGEqual returns whether the SVG documents got and want are identical
+// apart from numbers that differ by no more than tol. Numbers are compared
+// wherever they appear in the text, so coordinates that are rendered with
+// different rounding compare equal.
+func SVGEqual(got, want []byte, tol float64) bool {
+	gi := number.FindAllIndex(got, -1)
+	wi := number.FindAllIndex(want, -1)
+	if len(gi) != len(wi) {
+		return false
+	}
+	var gEnd, wEnd int
+	for k := range gi {
+		if string(got[gEnd:gi[k][0]]) != string(want[wEnd:wi[k][0]]) {
+			return false
+		}
+		g, err := strconv.ParseFloat(string(got[gi[k][0]:gi[k][1]]), 64)
+		if err != nil {
+			return false
+		}
+		w, err := strconv.ParseFloat(string(want[wi[k][0]:wi[k][1]]), 64)
+		if err != nil {
+			return false
+		}
+		if math.Abs(g-w) > tol {
+			return false
+		}
+		gEnd, wEnd = gi[k][1], wi[k][1]
+	}
+	return string(got[gEnd:]) == string(want[wEnd:])
+}
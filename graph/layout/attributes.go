@@ -0,0 +1,94 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+)
+
+// PositionKey is the attribute key for node positions, following the
+// Graphviz pos attribute.
+const PositionKey = "pos"
+
+// PositionAttribute returns p formatted as a Graphviz pos attribute. If
+// pin is true the position is marked as fixed for Graphviz layout engines.
+func PositionAttribute(p Point, pin bool) encoding.Attribute {
+	v := strconv.FormatFloat(p.X, 'g', -1, 64) + "," + strconv.FormatFloat(p.Y, 'g', -1, 64)
+	if pin {
+		v += "!"
+	}
+	return encoding.Attribute{Key: PositionKey, Value: v}
+}
+
+// SetAttributes sets the pos attribute of each node of g that has a
+// position in l and implements encoding.AttributeSetter. This allows a
+// layout to be written by the encoding/dot package. If pin is true the
+// positions are marked as fixed. The first error returned by a node's
+// SetAttribute method is returned.
+func SetAttributes(g graph.Graph, l Layout, pin bool) error {
+	for _, n := range sortedNodes(g) {
+		p, ok := l[n.ID()]
+		if !ok {
+			continue
+		}
+		s, ok := n.(encoding.AttributeSetter)
+		if !ok {
+			continue
+		}
+		err := s.SetAttribute(PositionAttribute(p, pin))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FromAttributes returns the layout held in the pos attributes of the
+// nodes of g that implement encoding.Attributer, such as graphs read by
+// the encoding/dot package after layout by Graphviz. Nodes without a pos
+// attribute are not included in the returned layout.
+func FromAttributes(g graph.Graph) (Layout, error) {
+	l := make(Layout)
+	for _, n := range sortedNodes(g) {
+		a, ok := n.(encoding.Attributer)
+		if !ok {
+			continue
+		}
+		for _, attr := range a.Attributes() {
+			if attr.Key != PositionKey {
+				continue
+			}
+			p, err := parsePos(attr.Value)
+			if err != nil {
+				return nil, fmt.Errorf("layout: invalid position for node %d: %v", n.ID(), err)
+			}
+			l[n.ID()] = p
+		}
+	}
+	return l, nil
+}
+
+// parsePos parses a two-dimensional Graphviz point, "x,y" with an
+// optional trailing "!".
+func parsePos(s string) (Point, error) {
+	f := strings.Split(strings.TrimSuffix(strings.TrimSpace(s), "!"), ",")
+	if len(f) != 2 {
+		return Point{}, fmt.Errorf("malformed point %q", s)
+	}
+	x, err := strconv.ParseFloat(strings.TrimSpace(f[0]), 64)
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := strconv.ParseFloat(strings.TrimSpace(f[1]), 64)
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{X: x, Y: y}, nil
+}
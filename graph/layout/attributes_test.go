@@ -0,0 +1,74 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// attrNode is a node with a set of attributes.
+type attrNode struct {
+	id    int64
+	attrs map[string]string
+}
+
+func (n attrNode) ID() int64 { return n.id }
+
+func (n attrNode) Attributes() []encoding.Attribute {
+	var a []encoding.Attribute
+	for k, v := range n.attrs {
+		a = append(a, encoding.Attribute{Key: k, Value: v})
+	}
+	return a
+}
+
+func (n attrNode) SetAttribute(attr encoding.Attribute) error {
+	n.attrs[attr.Key] = attr.Value
+	return nil
+}
+
+func TestAttributes(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	for id := int64(0); id < 4; id++ {
+		g.AddNode(attrNode{id: id, attrs: map[string]string{"label": "x"}})
+	}
+	// Nodes that cannot hold attributes are ignored.
+	g.AddNode(simple.Node(4))
+
+	l := Layout{0: {X: 1, Y: 2}, 1: {X: -0.5, Y: 1e6}, 2: {X: 3}, 4: {X: 1, Y: 1}}
+	err := SetAttributes(g, l, true)
+	if err != nil {
+		t.Fatalf("unexpected error setting attributes: %v", err)
+	}
+	if got := g.Node(1).(attrNode).attrs[PositionKey]; got != "-0.5,1e+06!" {
+		t.Errorf("unexpected pos attribute: got:%q want:%q", got, "-0.5,1e+06!")
+	}
+	if _, ok := g.Node(3).(attrNode).attrs[PositionKey]; ok {
+		t.Error("unexpected pos attribute for node without position")
+	}
+
+	got, err := FromAttributes(g)
+	if err != nil {
+		t.Fatalf("unexpected error reading attributes: %v", err)
+	}
+	want := Layout{0: l[0], 1: l[1], 2: l[2]}
+	if len(got) != len(want) {
+		t.Errorf("unexpected layout: got:%v want:%v", got, want)
+	}
+	for id, p := range want {
+		if got[id] != p {
+			t.Errorf("unexpected position for node %d: got:%v want:%v", id, got[id], p)
+		}
+	}
+
+	g.Node(3).(attrNode).attrs[PositionKey] = "1,2,3"
+	_, err = FromAttributes(g)
+	if err == nil {
+		t.Error("expected error for three-dimensional position")
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Distancer computes the graph-theoretic distances used by distance-based
+// layouts. Implementations may return exact or approximate distances, or
+// distances taken from a precomputed source.
+type Distancer interface {
+	// Distances sets dst[j] to the distance from nodes[from]
+	// to nodes[j] in g, or +Inf if nodes[j] is unreachable.
+	// The lengths of dst and nodes are equal, and indexOf
+	// maps the ID of each node in nodes to its index.
+	Distances(dst []float64, g graph.Graph, nodes []graph.Node, indexOf map[int64]int, from int)
+
+	// EdgeLength returns the length of the edge between
+	// the nodes with IDs uid and vid in g.
+	EdgeLength(g graph.Graph, uid, vid int64) float64
+}
+
+// adjacencyDistancer is a Distancer that can compute distances over
+// a precomputed adjacency, avoiding repeated traversal of the graph
+// when distances from many nodes are needed.
+type adjacencyDistancer interface {
+	Distancer
+
+	// adjacencyDistances sets dst[j] to the distance
+	// from node from to node j in adj.
+	adjacencyDistances(dst []float64, adj *adjacency, from int)
+}
+
+// Hops is a Distancer that measures distances as the number of edges on
+// a shortest path, computed by breadth-first search. Edge weights are
+// ignored.
+type Hops struct{}
+
+// Distances implements the Distancer interface.
+func (h Hops) Distances(dst []float64, g graph.Graph, nodes []graph.Node, indexOf map[int64]int, from int) {
+	newAdjacency(g, nodes, indexOf, h).bfs(dst, from)
+}
+
+func (Hops) adjacencyDistances(dst []float64, adj *adjacency, from int) {
+	adj.bfs(dst, from)
+}
+
+// EdgeLength implements the Distancer interface. It always returns 1.
+func (Hops) EdgeLength(graph.Graph, int64, int64) float64 { return 1 }
+
+// Dijkstra is a Distancer that measures distances as the total weight of
+// a shortest path, computed by Dijkstra's algorithm. If the graph is a
+// graph.Weighted, edge weights are obtained from its Weight method,
+// otherwise each edge has a weight of 1. Dijkstra will panic if it
+// encounters a negative edge weight.
+type Dijkstra struct{}
+
+// Distances implements the Distancer interface.
+func (d Dijkstra) Distances(dst []float64, g graph.Graph, nodes []graph.Node, indexOf map[int64]int, from int) {
+	newAdjacency(g, nodes, indexOf, d).dijkstra(dst, from)
+}
+
+func (Dijkstra) adjacencyDistances(dst []float64, adj *adjacency, from int) {
+	adj.dijkstra(dst, from)
+}
+
+// EdgeLength implements the Distancer interface.
+func (Dijkstra) EdgeLength(g graph.Graph, uid, vid int64) float64 {
+	wg, ok := g.(graph.Weighted)
+	if !ok {
+		return 1
+	}
+	w, ok := wg.Weight(uid, vid)
+	if !ok {
+		return math.Inf(1)
+	}
+	return w
+}
+
+// Landmarks is a Distancer that approximates distances by the shortest
+// route through one of a set of landmark nodes. The approximation is an
+// upper bound on the true distance and is exact for distances from a
+// landmark. After construction, the distance from one node to all others
+// is found in time proportional to the number of nodes and landmarks,
+// without traversing the graph.
+type Landmarks struct {
+	base Distancer
+	k    int
+	row  map[int64]int
+	dist []float64
+}
+
+// NewLandmarks returns a Landmarks for g using k landmarks chosen by
+// max-min selection starting from the node with the lowest ID, with
+// distances to the landmarks computed by base. If base is nil, Hops
+// is used. NewLandmarks will panic if k is not positive.
+//
+// The returned Landmarks is only valid for use with g.
+func NewLandmarks(g graph.Graph, k int, base Distancer) *Landmarks {
+	if k <= 0 {
+		panic("layout: number of landmarks not positive")
+	}
+	if base == nil {
+		base = Hops{}
+	}
+	nodes := sortedNodes(g)
+	row := make(map[int64]int, len(nodes))
+	for i, u := range nodes {
+		row[u.ID()] = i
+	}
+	var adj *adjacency
+	if _, ok := base.(adjacencyDistancer); ok {
+		adj = newAdjacency(g, nodes, row, base)
+	}
+	dist, landmarks, _ := maxMinPivots(g, nodes, row, adj, base, k)
+	return &Landmarks{base: base, k: len(landmarks), row: row, dist: dist}
+}
+
+// Distances implements the Distancer interface. It will panic if a
+// node in nodes was not in the graph that l was constructed for.
+func (l *Landmarks) Distances(dst []float64, _ graph.Graph, nodes []graph.Node, _ map[int64]int, from int) {
+	k := l.k
+	r, ok := l.row[nodes[from].ID()]
+	if !ok {
+		panic("layout: node not in landmark graph")
+	}
+	src := l.dist[r*k : (r+1)*k]
+	for j, v := range nodes {
+		if j == from {
+			dst[j] = 0
+			continue
+		}
+		r, ok := l.row[v.ID()]
+		if !ok {
+			panic("layout: node not in landmark graph")
+		}
+		d := math.Inf(1)
+		for a, da := range l.dist[r*k : (r+1)*k] {
+			d = math.Min(d, src[a]+da)
+		}
+		dst[j] = d
+	}
+}
+
+// EdgeLength implements the Distancer interface using
+// the base Distancer of l.
+func (l *Landmarks) EdgeLength(g graph.Graph, uid, vid int64) float64 {
+	return l.base.EdgeLength(g, uid, vid)
+}
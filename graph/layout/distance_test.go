@@ -0,0 +1,119 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// weightedPath returns a path of n nodes with edge i weighted by w(i).
+func weightedPath(n int, w func(int) float64) *simple.WeightedUndirectedGraph {
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for i := 1; i < n; i++ {
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i - 1), T: simple.Node(i), W: w(i)})
+	}
+	return g
+}
+
+func allDistances(g graph.Graph, d Distancer) [][]float64 {
+	nodes := sortedNodes(g)
+	indexOf := make(map[int64]int, len(nodes))
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+	dist := make([][]float64, len(nodes))
+	for i := range nodes {
+		dist[i] = make([]float64, len(nodes))
+		d.Distances(dist[i], g, nodes, indexOf, i)
+	}
+	return dist
+}
+
+func TestDijkstraUnweighted(t *testing.T) {
+	g := grid(4, 6)
+	g.AddNode(simple.Node(100))
+	hops := allDistances(g, Hops{})
+	dijkstra := allDistances(g, Dijkstra{})
+	for i := range hops {
+		for j := range hops[i] {
+			if hops[i][j] != dijkstra[i][j] {
+				t.Errorf("unexpected distance from %d to %d: got:%v want:%v", i, j, dijkstra[i][j], hops[i][j])
+			}
+		}
+	}
+}
+
+func TestDijkstraWeighted(t *testing.T) {
+	g := weightedPath(4, func(i int) float64 { return float64(i) })
+	// Add a shortcut that is longer than the path it bypasses.
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(2), W: 3.5})
+	// Add a shortcut that is shorter than the path it bypasses.
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(3), W: 4})
+	want := [][]float64{
+		{0, 1, 3, 4},
+		{1, 0, 2, 5},
+		{3, 2, 0, 3},
+		{4, 5, 3, 0},
+	}
+	got := allDistances(g, Dijkstra{})
+	for i := range want {
+		for j := range want[i] {
+			if got[i][j] != want[i][j] {
+				t.Errorf("unexpected distance from %d to %d: got:%v want:%v", i, j, got[i][j], want[i][j])
+			}
+		}
+	}
+	if l := (Dijkstra{}).EdgeLength(g, 2, 3); l != 3 {
+		t.Errorf("unexpected edge length: got:%v want:3", l)
+	}
+}
+
+func TestLandmarks(t *testing.T) {
+	g := grid(6, 7)
+	exact := allDistances(g, Hops{})
+	l := NewLandmarks(g, 5, nil)
+	approx := allDistances(g, l)
+	nodes := sortedNodes(g)
+	for i := range exact {
+		isLandmark := true
+		for j := range exact[i] {
+			if approx[i][j] < exact[i][j] {
+				t.Errorf("landmark distance from %d to %d below exact distance: got:%v exact:%v",
+					nodes[i].ID(), nodes[j].ID(), approx[i][j], exact[i][j])
+			}
+			if approx[i][j] != exact[i][j] {
+				isLandmark = false
+			}
+		}
+		// The first landmark is the lowest ID node.
+		if i == 0 && !isLandmark {
+			t.Errorf("inexact distances from landmark node %d", nodes[i].ID())
+		}
+	}
+}
+
+func TestPivotMDSWeighted(t *testing.T) {
+	const n = 8
+	g := weightedPath(n, func(int) float64 { return 2 })
+	l := PivotMDS(g, 3, Dijkstra{})
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			got := dist(l[int64(i)], l[int64(j)])
+			if want := 2 * float64(i-j); math.Abs(got-want) > 1e-6 {
+				t.Errorf("unexpected distance between %d and %d: got:%v want:%v", i, j, got, want)
+			}
+		}
+	}
+
+	before := stress(g, l) // Hop stress, which is poor for a doubled layout.
+	refined := SparseStress(g, l, 3, 10, Hops{})
+	if after := stress(g, refined); after >= before {
+		t.Errorf("refinement with hop distances did not reduce hop stress: before:%v after:%v", before, after)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Grouped returns a layout of g that places the nodes of each group
+// together. The groups map holds the group of each grouped node by ID;
+// nodes that are not in groups each form a group of their own.
+//
+// Each group is first laid out by SparseStress on the subgraph induced by
+// its nodes and centered on the origin. The groups are then contracted to
+// super-nodes, with super-nodes adjacent when an edge of g joins their
+// groups, and the contracted graph is laid out by SparseStress with the
+// length of each edge set to the sum of the radii of the two group layouts
+// plus one. Larger groups are thus held further from their neighbors, so
+// that adjacent groups do not overlap. Finally each group layout is moved
+// to the position of its super-node. This is much faster than a layout of
+// all of g when the groups are many or large, and shows the structure
+// between the groups clearly.
+//
+// The edges of g are treated as undirected and of unit length. As with
+// SparseStress, nodes in different connected components of the contracted
+// graph or of the subgraph of a group do not interact through pivot terms.
+// Grouped will panic if pivots is not positive or iterations is negative.
+func Grouped(g graph.Graph, groups map[int64]int64, pivots, iterations int) Layout {
+	if pivots <= 0 {
+		panic("layout: number of pivots not positive")
+	}
+	if iterations < 0 {
+		panic("layout: negative number of iterations")
+	}
+
+	// Number the groups in order of their lowest node ID.
+	nodes := sortedNodes(g)
+	super := make(map[int64]int64, len(nodes))
+	index := make(map[int64]int64)
+	var members [][]graph.Node
+	for _, u := range nodes {
+		label, ok := groups[u.ID()]
+		s, seen := index[label]
+		if !ok || !seen {
+			s = int64(len(members))
+			members = append(members, nil)
+			if ok {
+				index[label] = s
+			}
+		}
+		super[u.ID()] = s
+		members[s] = append(members[s], u)
+	}
+
+	// Lay out each group in its own frame.
+	sub := make([]*simple.UndirectedGraph, len(members))
+	for s, m := range members {
+		sub[s] = simple.NewUndirectedGraph()
+		for _, u := range m {
+			sub[s].AddNode(u)
+		}
+	}
+	contracted := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for s := range members {
+		contracted.AddNode(simple.Node(s))
+	}
+	var crossing []graph.Edge
+	for _, u := range nodes {
+		to := g.From(u.ID())
+		for to.Next() {
+			v := to.Node()
+			su, sv := super[u.ID()], super[v.ID()]
+			switch {
+			case u.ID() == v.ID():
+			case su == sv:
+				sub[su].SetEdge(simple.Edge{F: u, T: v})
+			default:
+				crossing = append(crossing, simple.Edge{F: simple.Node(su), T: simple.Node(sv)})
+			}
+		}
+	}
+	local := make([]Layout, len(members))
+	radius := make([]float64, len(members))
+	for s, m := range members {
+		if len(m) == 1 {
+			local[s] = Layout{m[0].ID(): {}}
+			continue
+		}
+		l := SparseStress(sub[s], nil, pivots, iterations, nil)
+		var cx, cy float64
+		for _, u := range m {
+			cx += l[u.ID()].X
+			cy += l[u.ID()].Y
+		}
+		cx /= float64(len(m))
+		cy /= float64(len(m))
+		for _, u := range m {
+			p := l[u.ID()]
+			p = Point{X: p.X - cx, Y: p.Y - cy}
+			l[u.ID()] = p
+			radius[s] = math.Max(radius[s], math.Hypot(p.X, p.Y))
+		}
+		local[s] = l
+	}
+
+	// Lay out the contracted graph with edges long
+	// enough to keep adjacent groups apart.
+	for _, e := range crossing {
+		su, sv := e.From().ID(), e.To().ID()
+		contracted.SetWeightedEdge(simple.WeightedEdge{F: e.From(), T: e.To(), W: radius[su] + radius[sv] + 1})
+	}
+	centers := SparseStress(contracted, nil, pivots, iterations, Dijkstra{})
+
+	layout := make(Layout, len(nodes))
+	for s, l := range local {
+		c := centers[int64(s)]
+		for id, p := range l {
+			layout[id] = Point{X: c.X + p.X, Y: c.Y + p.Y}
+		}
+	}
+	return layout
+}
@@ -0,0 +1,74 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestGrouped(t *testing.T) {
+	// A chain of three grid groups joined by single
+	// edges, with an ungrouped node on the last.
+	const rows, cols = 5, 6
+	g := simple.NewUndirectedGraph()
+	groups := make(map[int64]int64)
+	for k, label := range []int64{30, 10, 20} {
+		base := int64(k * rows * cols)
+		gr := grid(rows, cols)
+		edges := gr.Edges()
+		for edges.Next() {
+			e := edges.Edge()
+			g.SetEdge(simple.Edge{F: simple.Node(base + e.From().ID()), T: simple.Node(base + e.To().ID())})
+		}
+		for id := base; id < base+rows*cols; id++ {
+			groups[id] = label
+		}
+		if k > 0 {
+			g.SetEdge(simple.Edge{F: simple.Node(base - 1), T: simple.Node(base)})
+		}
+	}
+	pendant := int64(3 * rows * cols)
+	g.SetEdge(simple.Edge{F: simple.Node(pendant - 1), T: simple.Node(pendant)})
+
+	l := Grouped(g, groups, 10, 50)
+	if len(l) != g.Nodes().Len() {
+		t.Fatalf("unexpected number of positions: got:%d want:%d", len(l), g.Nodes().Len())
+	}
+
+	// Each node is nearer the center of its
+	// own group than the center of any other.
+	centers := make(map[int64]Point)
+	for id, label := range groups {
+		c := centers[label]
+		c.X += l[id].X / (rows * cols)
+		c.Y += l[id].Y / (rows * cols)
+		centers[label] = c
+	}
+	for id, label := range groups {
+		own := dist(l[id], centers[label])
+		for other, c := range centers {
+			if other != label && dist(l[id], c) <= own {
+				t.Errorf("node %d of group %d nearer center of group %d", id, label, other)
+			}
+		}
+	}
+
+	// Within a group the layout is that of the group alone.
+	want := SparseStress(grid(rows, cols), nil, 10, 50, nil)
+	for id, p := range want {
+		q, r := l[id], l[0]
+		got := math.Hypot(q.X-r.X, q.Y-r.Y)
+		if w := dist(p, want[0]); math.Abs(got-w) > 1e-9 {
+			t.Errorf("unexpected distance between nodes %d and 0: got:%v want:%v", id, got, w)
+		}
+	}
+
+	if got := Grouped(simple.NewUndirectedGraph(), nil, 1, 1); len(got) != 0 {
+		t.Errorf("unexpected layout of empty graph: got:%v", got)
+	}
+}
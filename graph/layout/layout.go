@@ -0,0 +1,131 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package layout provides graph layout algorithms based on graph-theoretic
+// distances between nodes.
+package layout
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"gonum.org/v1/exp/spatial/r2"
+	"gonum.org/v1/gonum/graph"
+)
+
+// Point is a position in the plane.
+type Point = r2.Vec
+
+// Layout holds node positions keyed by node ID.
+type Layout map[int64]Point
+
+// sortedNodes returns the nodes of g sorted by ascending ID.
+func sortedNodes(g graph.Graph) []graph.Node {
+	it := g.Nodes()
+	nodes := make([]graph.Node, 0, it.Len())
+	for it.Next() {
+		nodes = append(nodes, it.Node())
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
+	return nodes
+}
+
+// adjacency is a compressed sparse row representation of the edges of
+// a graph over node indices. The neighbors of node i are held in
+// to[start[i]:start[i+1]] and the lengths of the edges to them in the
+// same elements of length.
+type adjacency struct {
+	start  []int
+	to     []int
+	length []float64
+}
+
+// newAdjacency returns the adjacency of the edges from each of nodes in
+// g, with edge lengths given by d. indexOf maps the ID of each node in
+// nodes to its index.
+func newAdjacency(g graph.Graph, nodes []graph.Node, indexOf map[int64]int, d Distancer) *adjacency {
+	a := &adjacency{start: make([]int, len(nodes)+1)}
+	for i, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			a.to = append(a.to, indexOf[vid])
+			a.length = append(a.length, d.EdgeLength(g, uid, vid))
+		}
+		a.start[i+1] = len(a.to)
+	}
+	return a
+}
+
+// bfs sets dst[j] to the number of hops from node from to node j.
+// Unreachable nodes are set to +Inf.
+func (a *adjacency) bfs(dst []float64, from int) {
+	for i := range dst {
+		dst[i] = math.Inf(1)
+	}
+	dst[from] = 0
+	queue := make([]int, 1, len(dst))
+	queue[0] = from
+	for h := 0; h < len(queue); h++ {
+		u := queue[h]
+		for _, v := range a.to[a.start[u]:a.start[u+1]] {
+			if math.IsInf(dst[v], 1) {
+				dst[v] = dst[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+}
+
+// dijkstra sets dst[j] to the length of the shortest path from node
+// from to node j. Unreachable nodes are set to +Inf. dijkstra will
+// panic if it encounters a negative edge length.
+func (a *adjacency) dijkstra(dst []float64, from int) {
+	for i := range dst {
+		dst[i] = math.Inf(1)
+	}
+	dst[from] = 0
+	visited := make([]bool, len(dst))
+	q := distanceQueue{{idx: from}}
+	for q.Len() != 0 {
+		u := heap.Pop(&q).(distanceItem)
+		if visited[u.idx] {
+			continue
+		}
+		visited[u.idx] = true
+		for k := a.start[u.idx]; k < a.start[u.idx+1]; k++ {
+			v, w := a.to[k], a.length[k]
+			if w < 0 {
+				panic("layout: negative edge weight")
+			}
+			if alt := u.dist + w; alt < dst[v] {
+				dst[v] = alt
+				heap.Push(&q, distanceItem{idx: v, dist: alt})
+			}
+		}
+	}
+}
+
+// distanceItem is a node index and its tentative distance.
+type distanceItem struct {
+	idx  int
+	dist float64
+}
+
+// distanceQueue is a min-priority queue of distanceItems.
+type distanceQueue []distanceItem
+
+func (q distanceQueue) Len() int            { return len(q) }
+func (q distanceQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q distanceQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *distanceQueue) Push(x interface{}) { *q = append(*q, x.(distanceItem)) }
+func (q *distanceQueue) Pop() interface{} {
+	old := *q
+	n := len(old) - 1
+	item := old[n]
+	*q = old[:n]
+	return item
+}
@@ -0,0 +1,299 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// PivotMDS returns a layout of g computed by pivot multidimensional
+// scaling, an approximation of classical multidimensional scaling of
+// the graph-theoretic distances between nodes that uses only the
+// distances from a small number of pivot nodes.
+//
+// Pivots are chosen by max-min selection starting from the node with
+// the lowest ID, each subsequent pivot being the node farthest from
+// all pivots chosen so far. If pivots is greater than the number of
+// nodes in g, every node is a pivot and the result is the classical
+// multidimensional scaling of g. Distances are computed by d, or are hop
+// counts if d is nil. Nodes that are unreachable from a pivot are treated
+// as being one unit further than the farthest reachable node. The returned
+// layout is scaled to fit the finite pivot distances in the least squares
+// sense, so that a unit of length in the layout is a unit of distance.
+//
+// The running time is linear in the size of g for a fixed number of
+// pivots, making PivotMDS suitable as an initial layout for very large
+// graphs that is then refined by a stress or force-directed method.
+// PivotMDS will panic if pivots is not positive.
+//
+// References:
+//   - Brandes, U. and Pich, C. (2007). Eigensolver methods for progressive
+//     multidimensional scaling of large data. In Graph Drawing (pp. 42-53).
+//     Springer.
+func PivotMDS(g graph.Graph, pivots int, d Distancer) Layout {
+	if pivots <= 0 {
+		panic("layout: number of pivots not positive")
+	}
+	nodes := sortedNodes(g)
+	n := len(nodes)
+	if n == 0 {
+		return make(Layout)
+	}
+	if d == nil {
+		d = Hops{}
+	}
+	indexOf := make(map[int64]int, n)
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+	var adj *adjacency
+	if _, ok := d.(adjacencyDistancer); ok {
+		adj = newAdjacency(g, nodes, indexOf, d)
+	}
+	dist, pivotIdx, maxDist := maxMinPivots(g, nodes, indexOf, adj, d, pivots)
+	return pivotMDSLayout(nodes, dist, pivotIdx, maxDist)
+}
+
+// pivotMDSLayout returns the PivotMDS layout of nodes given the row-major
+// len(nodes)×len(pivots) matrix of distances from each node to each pivot
+// and the maximum finite distance, as returned by maxMinPivots.
+func pivotMDSLayout(nodes []graph.Node, dist []float64, pivots []int, maxDist float64) Layout {
+	k := len(pivots)
+	layout := make(Layout, len(nodes))
+	c := make([]float64, len(dist))
+	copy(c, dist)
+	pivotMDSProject(layout, nodes, c, k, maxDist+1)
+
+	// The projection preserves the shape of the layout but only
+	// approximates its scale when not all nodes are pivots. Rescale
+	// to the least squares fit of the finite pivot distances.
+	var num, den float64
+	for i, u := range nodes {
+		pu := layout[u.ID()]
+		for j, p := range pivots {
+			dp := dist[i*k+j]
+			if math.IsInf(dp, 1) {
+				continue
+			}
+			pp := layout[nodes[p].ID()]
+			e := math.Hypot(pu.X-pp.X, pu.Y-pp.Y)
+			num += dp * e
+			den += e * e
+		}
+	}
+	if den != 0 {
+		s := num / den
+		for id, p := range layout {
+			layout[id] = Point{X: s * p.X, Y: s * p.Y}
+		}
+	}
+	return layout
+}
+
+// maxMinPivots chooses up to k pivots from nodes by max-min selection
+// starting from the first node, and returns the row-major len(nodes)×k
+// matrix of distances computed by d from each node to each pivot, the
+// indices of the pivots and the maximum finite distance. Unreachable
+// nodes have an infinite distance. indexOf maps the ID of each node in
+// nodes to its index. If adj is not nil and d is an adjacencyDistancer,
+// distances are computed over adj, which must be the adjacency of g.
+func maxMinPivots(g graph.Graph, nodes []graph.Node, indexOf map[int64]int, adj *adjacency, d Distancer, k int) (dist []float64, pivots []int, maxDist float64) {
+	n := len(nodes)
+	if k > n {
+		k = n
+	}
+	dist = make([]float64, n*k)
+	pivots = make([]int, k)
+	minDist := make([]float64, n)
+	for i := range minDist {
+		minDist[i] = math.Inf(1)
+	}
+	// Traverse the compact copy of g when d allows it, so that
+	// each pivot does not repeat the graph and ID lookups.
+	distances := func(dst []float64, from int) {
+		d.Distances(dst, g, nodes, indexOf, from)
+	}
+	if ad, ok := d.(adjacencyDistancer); ok && adj != nil {
+		distances = func(dst []float64, from int) {
+			ad.adjacencyDistances(dst, adj, from)
+		}
+	}
+	col := make([]float64, n)
+	for j, p := 0, 0; j < k; j++ {
+		pivots[j] = p
+		distances(col, p)
+		for i, v := range col {
+			dist[i*k+j] = v
+			if !math.IsInf(v, 1) {
+				maxDist = math.Max(maxDist, v)
+			}
+			minDist[i] = math.Min(minDist[i], v)
+		}
+		for i, v := range minDist {
+			if v > minDist[p] {
+				p = i
+			}
+		}
+	}
+	return dist, pivots, maxDist
+}
+
+// pivotMDSProject sets the positions of nodes in dst from the n×k
+// matrix c of distances from k pivots, replacing infinite distances
+// with unreachable. The contents of c are overwritten.
+func pivotMDSProject(dst Layout, nodes []graph.Node, c []float64, k int, unreachable float64) {
+	n := len(nodes)
+
+	// Double center the squared distances.
+	rowMean := make([]float64, n)
+	colMean := make([]float64, k)
+	var mean float64
+	for i := 0; i < n; i++ {
+		row := c[i*k : (i+1)*k]
+		for j, d := range row {
+			if math.IsInf(d, 1) {
+				d = unreachable
+			}
+			d *= d
+			row[j] = d
+			rowMean[i] += d
+			colMean[j] += d
+			mean += d
+		}
+		rowMean[i] /= float64(k)
+	}
+	for j := range colMean {
+		colMean[j] /= float64(n)
+	}
+	mean /= float64(n * k)
+	for i := 0; i < n; i++ {
+		row := c[i*k : (i+1)*k]
+		for j := range row {
+			row[j] = -0.5 * (row[j] - rowMean[i] - colMean[j] + mean)
+		}
+	}
+
+	// Form cᵀc and find its two leading eigenvectors.
+	// Only the upper triangle is accumulated; cᵀc is symmetric.
+	ctc := make([]float64, k*k)
+	for i := 0; i < n; i++ {
+		row := c[i*k : (i+1)*k]
+		for a, va := range row {
+			dst := ctc[a*k : (a+1)*k]
+			for b := a; b < k; b++ {
+				dst[b] += va * row[b]
+			}
+		}
+	}
+	for a := 0; a < k; a++ {
+		for b := 0; b < a; b++ {
+			ctc[a*k+b] = ctc[b*k+a]
+		}
+	}
+	vals, vecs := leadingEigenSym(ctc, k, 2)
+
+	// Project onto the eigenvectors. Each eigenvalue of cᵀc approximates
+	// the square of an eigenvalue of the double centered squared distance
+	// matrix, so coordinates are scaled to match classical scaling.
+	// Eigenvalues that are negligible relative to the leading eigenvalue
+	// are the result of rounding and are treated as zero.
+	var scale [2]float64
+	for d := range scale {
+		if vals[d] > 1e-12*vals[0] {
+			scale[d] = math.Pow(vals[d], -0.25)
+		}
+	}
+	for i, u := range nodes {
+		row := c[i*k : (i+1)*k]
+		var p [2]float64
+		for d := range p {
+			for a, v := range row {
+				p[d] += v * vecs[d][a]
+			}
+			p[d] *= scale[d]
+		}
+		dst[u.ID()] = Point{X: p[0], Y: p[1]}
+	}
+}
+
+// leadingEigenSym returns the m largest eigenvalues and corresponding unit
+// eigenvectors of the k×k symmetric positive semi-definite matrix a stored
+// in row-major order, using power iteration with deflation. If m exceeds k,
+// the excess eigenvalues and eigenvectors are zero. The contents of a are
+// overwritten.
+func leadingEigenSym(a []float64, k, m int) (vals []float64, vecs [][]float64) {
+	const (
+		maxIter = 1000
+		tol     = 1e-12
+	)
+	vals = make([]float64, m)
+	vecs = make([][]float64, m)
+	tmp := make([]float64, k)
+	for d := 0; d < m; d++ {
+		v := make([]float64, k)
+		vecs[d] = v
+		if d >= k {
+			continue
+		}
+		for i := range v {
+			v[i] = 1 / float64(i+d+1)
+		}
+		normalize(v)
+		var lambda float64
+		for iter := 0; iter < maxIter; iter++ {
+			for i := range tmp {
+				var s float64
+				for j, vj := range v {
+					s += a[i*k+j] * vj
+				}
+				tmp[i] = s
+			}
+			lambda = normalize(tmp)
+			if lambda == 0 {
+				break
+			}
+			var diff float64
+			for i, t := range tmp {
+				diff = math.Max(diff, math.Abs(t-v[i]))
+			}
+			copy(v, tmp)
+			if diff < tol {
+				break
+			}
+		}
+		if lambda == 0 {
+			for i := range v {
+				v[i] = 0
+			}
+			continue
+		}
+		vals[d] = lambda
+		// Deflate a by the found eigenpair.
+		for i := 0; i < k; i++ {
+			for j := 0; j < k; j++ {
+				a[i*k+j] -= lambda * v[i] * v[j]
+			}
+		}
+	}
+	return vals, vecs
+}
+
+// normalize scales v to unit Euclidean length and returns its original
+// length. If v is zero, it is left unchanged.
+func normalize(v []float64) float64 {
+	var norm float64
+	for _, e := range v {
+		norm = math.Hypot(norm, e)
+	}
+	if norm == 0 {
+		return 0
+	}
+	for i := range v {
+		v[i] /= norm
+	}
+	return norm
+}
@@ -0,0 +1,129 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func path(n int) *simple.UndirectedGraph {
+	g := simple.NewUndirectedGraph()
+	for i := 1; i < n; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i - 1), T: simple.Node(i)})
+	}
+	return g
+}
+
+func grid(rows, cols int) *simple.UndirectedGraph {
+	g := simple.NewUndirectedGraph()
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			u := simple.Node(r*cols + c)
+			if c+1 < cols {
+				g.SetEdge(simple.Edge{F: u, T: simple.Node(r*cols + c + 1)})
+			}
+			if r+1 < rows {
+				g.SetEdge(simple.Edge{F: u, T: simple.Node((r+1)*cols + c)})
+			}
+		}
+	}
+	return g
+}
+
+func dist(a, b Point) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
+
+func TestPivotMDSPath(t *testing.T) {
+	const n = 10
+	for _, pivots := range []int{3, n, 2 * n} {
+		l := PivotMDS(path(n), pivots, nil)
+		if len(l) != n {
+			t.Fatalf("unexpected layout size with %d pivots: got:%d want:%d", pivots, len(l), n)
+		}
+		// A path is exactly embeddable on a line.
+		for i := 0; i < n; i++ {
+			for j := 0; j < i; j++ {
+				got := dist(l[int64(i)], l[int64(j)])
+				if math.Abs(got-float64(i-j)) > 1e-6 {
+					t.Errorf("unexpected distance between %d and %d with %d pivots: got:%v want:%d",
+						i, j, pivots, got, i-j)
+				}
+			}
+		}
+	}
+}
+
+func TestPivotMDSGrid(t *testing.T) {
+	// The four corners of a grid should be laid out
+	// further from the center than all other nodes.
+	const rows, cols = 5, 7
+	l := PivotMDS(grid(rows, cols), 8, nil)
+	var cx, cy float64
+	for _, p := range l {
+		cx += p.X
+		cy += p.Y
+	}
+	c := Point{X: cx / float64(len(l)), Y: cy / float64(len(l))}
+	corners := map[int64]bool{0: true, cols - 1: true, (rows - 1) * cols: true, rows*cols - 1: true}
+	minCorner := math.Inf(1)
+	maxOther := math.Inf(-1)
+	for id, p := range l {
+		if corners[id] {
+			minCorner = math.Min(minCorner, dist(p, c))
+		} else {
+			maxOther = math.Max(maxOther, dist(p, c))
+		}
+	}
+	if minCorner <= maxOther {
+		t.Errorf("corners not outermost: closest corner:%v furthest other:%v", minCorner, maxOther)
+	}
+}
+
+func TestPivotMDSDegenerate(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		g    graph.Graph
+	}{
+		{name: "empty", g: simple.NewUndirectedGraph()},
+		{name: "single", g: func() graph.Graph {
+			g := simple.NewUndirectedGraph()
+			g.AddNode(simple.Node(3))
+			return g
+		}()},
+		{name: "disconnected", g: func() graph.Graph {
+			g := path(4)
+			g.SetEdge(simple.Edge{F: simple.Node(10), T: simple.Node(11)})
+			g.AddNode(simple.Node(20))
+			return g
+		}()},
+	} {
+		l := PivotMDS(test.g, 4, nil)
+		if len(l) != test.g.Nodes().Len() {
+			t.Errorf("unexpected layout size for %s: got:%d want:%d", test.name, len(l), test.g.Nodes().Len())
+		}
+		for id, p := range l {
+			if math.IsNaN(p.X) || math.IsNaN(p.Y) || math.IsInf(p.X, 0) || math.IsInf(p.Y, 0) {
+				t.Errorf("invalid position for node %d of %s: %v", id, test.name, p)
+			}
+		}
+	}
+}
+
+func BenchmarkPivotMDS(b *testing.B) {
+	for _, size := range []int{30, 300} {
+		g := grid(size, size)
+		b.Run(fmt.Sprintf("grid%dx%d", size, size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				PivotMDS(g, 50, nil)
+			}
+		})
+	}
+}
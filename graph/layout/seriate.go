@@ -0,0 +1,303 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/exp/linsolve"
+	"gonum.org/v1/gonum/graph"
+)
+
+// Seriate returns the nodes of g in a linear order that places adjacent
+// nodes close together, suitable for ordering the rows and columns of an
+// adjacency matrix or the nodes of an arc diagram.
+//
+// Each connected component is ordered by the Fiedler vector of its
+// Laplacian, the minimizer of the sum of squared differences between the
+// positions of adjacent nodes, and components are placed in order of their
+// lowest node ID. Within a component the order is oriented so that node
+// IDs tend to increase along it. The edges of g are treated as undirected
+// and unweighted.
+//
+// References:
+//   - Atkins, J. E., Boman, E. G. and Hendrickson, B. (1998). A spectral
+//     algorithm for seriation and the consecutive ones problem. SIAM
+//     Journal on Computing, 28(1), 297-310.
+func Seriate(g graph.Graph) []graph.Node {
+	nodes := sortedNodes(g)
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	// Collect the neighbors of each node, ignoring self loops.
+	adj := make([][]int, len(nodes))
+	for i, u := range nodes {
+		to := g.From(u.ID())
+		for to.Next() {
+			j := indexOf[to.Node().ID()]
+			if j != i {
+				adj[i] = append(adj[i], j)
+				adj[j] = append(adj[j], i)
+			}
+		}
+	}
+	// Remove the duplicates introduced by edges
+	// seen from both ends.
+	dedupe(adj)
+
+	order := make([]graph.Node, len(nodes))
+	for i, j := range seriateComponents(adj) {
+		order[i] = nodes[j]
+	}
+	return order
+}
+
+// SeriatePoints returns the indices of pts in a linear order that places
+// nearby points close together. The order is the spectral ordering used by
+// Seriate of the graph linking each point to its k nearest other points,
+// so points are ordered along the curves or manifolds they lie on rather
+// than by their coordinates. Components of the neighbor graph, which are
+// groups of more than k points separated from the others, are placed in
+// order of their lowest index and within a component the order is oriented
+// so that indices tend to increase along it. Ties in distance are broken
+// by index.
+//
+// Finding the neighbors takes time quadratic in the number of points.
+// SeriatePoints will panic if k is not positive or if a coordinate of a
+// point in pts is not finite.
+func SeriatePoints(pts []Point, k int) []int {
+	if k <= 0 {
+		panic("layout: number of neighbors not positive")
+	}
+	for _, p := range pts {
+		if math.IsInf(p.X, 0) || math.IsInf(p.Y, 0) || math.IsNaN(p.X) || math.IsNaN(p.Y) {
+			panic("layout: non-finite coordinate")
+		}
+	}
+	if k >= len(pts) {
+		k = len(pts) - 1
+	}
+
+	// Link each point to its k nearest others, keeping
+	// the candidates sorted by distance and then index.
+	adj := make([][]int, len(pts))
+	type neighbor struct {
+		j int
+		d float64
+	}
+	near := make([]neighbor, 0, k+1)
+	for i, p := range pts {
+		near = near[:0]
+		for j, q := range pts {
+			if j == i {
+				continue
+			}
+			d := math.Hypot(p.X-q.X, p.Y-q.Y)
+			if len(near) == k && d >= near[k-1].d {
+				continue
+			}
+			l := len(near)
+			for l > 0 && near[l-1].d > d {
+				l--
+			}
+			near = append(near, neighbor{})
+			copy(near[l+1:], near[l:])
+			near[l] = neighbor{j: j, d: d}
+			if len(near) > k {
+				near = near[:k]
+			}
+		}
+		for _, nb := range near {
+			adj[i] = append(adj[i], nb.j)
+			adj[nb.j] = append(adj[nb.j], i)
+		}
+	}
+	dedupe(adj)
+	return seriateComponents(adj)
+}
+
+// seriateComponents returns the spectral order of the nodes of the graph
+// held as sorted, duplicate-free adjacency lists of node indices. Each
+// connected component is ordered by fiedlerOrder and components are placed
+// in order of their lowest index.
+func seriateComponents(adj [][]int) []int {
+	order := make([]int, 0, len(adj))
+	seen := make([]bool, len(adj))
+	for i := range adj {
+		if seen[i] {
+			continue
+		}
+		seen[i] = true
+		comp := []int{i}
+		for k := 0; k < len(comp); k++ {
+			for _, j := range adj[comp[k]] {
+				if !seen[j] {
+					seen[j] = true
+					comp = append(comp, j)
+				}
+			}
+		}
+		sort.Ints(comp)
+		order = append(order, fiedlerOrder(adj, comp)...)
+	}
+	return order
+}
+
+// dedupe sorts each adjacency list in adj and removes its duplicates.
+func dedupe(adj [][]int) {
+	for i, nb := range adj {
+		sort.Ints(nb)
+		n := 0
+		for k, j := range nb {
+			if k == 0 || j != nb[n-1] {
+				nb[n] = j
+				n++
+			}
+		}
+		adj[i] = nb[:n]
+	}
+}
+
+// fiedlerOrder returns the node indices of the connected component comp,
+// in ascending order, sorted by their elements of the Fiedler vector of
+// the component's Laplacian, L.
+//
+// The Fiedler vector is found by inverse iteration, solving L x = v by
+// conjugate gradients with the constant null vector of L projected out.
+// The spectral gap of L shrinks quadratically with the diameter of the
+// component, which makes power iteration impractically slow, while the
+// convergence of inverse iteration depends only on the ratio of the two
+// smallest non-zero eigenvalues. The iteration is started from the hop
+// distances to a peripheral node, which is close to the Fiedler vector
+// for elongated components.
+func fiedlerOrder(adj [][]int, comp []int) []int {
+	const (
+		maxIter = 100
+		tol     = 1e-10
+	)
+	k := len(comp)
+	if k < 3 {
+		return comp
+	}
+	local := make(map[int]int, k)
+	for i, u := range comp {
+		local[u] = i
+	}
+	lap := make(laplacian, k)
+	for i, u := range comp {
+		lap[i] = make([]int, len(adj[u]))
+		for j, w := range adj[u] {
+			lap[i][j] = local[w]
+		}
+	}
+
+	dist := make([]float64, k)
+	lap.hops(dist, 0)
+	far := 0
+	for i, d := range dist {
+		if d > dist[far] {
+			far = i
+		}
+	}
+	lap.hops(dist, far)
+	v := dist
+	center(v)
+	normalize(v)
+
+	x := make([]float64, k)
+	settings := &linsolve.Settings{Tolerance: tol, Dst: x, Work: linsolve.NewContext(k)}
+	for iter := 0; iter < maxIter; iter++ {
+		_, err := linsolve.Iterative(lap, v, &linsolve.CG{}, settings)
+		if err != nil && err != linsolve.ErrIterationLimit {
+			// A breakdown leaves the current estimate
+			// as the best available.
+			break
+		}
+		center(x)
+		if normalize(x) == 0 {
+			break
+		}
+		var diff float64
+		for i, xi := range x {
+			diff = math.Max(diff, math.Abs(xi-v[i]))
+		}
+		copy(v, x)
+		if diff < tol {
+			break
+		}
+	}
+
+	// Orient the vector to correlate positively with node order.
+	var dir float64
+	for i, x := range v {
+		dir += float64(i) * x
+	}
+	if dir < 0 {
+		for i := range v {
+			v[i] = -v[i]
+		}
+	}
+
+	idx := make([]int, k)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return v[idx[i]] < v[idx[j]] })
+	order := make([]int, k)
+	for i, j := range idx {
+		order[i] = comp[j]
+	}
+	return order
+}
+
+// laplacian is the Laplacian matrix of an unweighted graph held as
+// adjacency lists of node indices.
+type laplacian [][]int
+
+// MulVecTo stores L x into dst. L is symmetric so trans is ignored.
+func (l laplacian) MulVecTo(dst []float64, _ bool, x []float64) {
+	for i, nb := range l {
+		s := float64(len(nb)) * x[i]
+		for _, j := range nb {
+			s -= x[j]
+		}
+		dst[i] = s
+	}
+}
+
+// hops sets dst[i] to the number of hops from node from to node i of
+// the connected graph l.
+func (l laplacian) hops(dst []float64, from int) {
+	for i := range dst {
+		dst[i] = -1
+	}
+	dst[from] = 0
+	queue := []int{from}
+	for len(queue) != 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, v := range l[u] {
+			if dst[v] < 0 {
+				dst[v] = dst[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+}
+
+// center subtracts the mean of v from each element of v.
+func center(v []float64) {
+	var mean float64
+	for _, x := range v {
+		mean += x
+	}
+	mean /= float64(len(v))
+	for i := range v {
+		v[i] -= mean
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestSeriate(t *testing.T) {
+	// Two paths with shuffled node IDs and an isolated node.
+	perm := rand.New(rand.NewSource(1)).Perm(30)
+	g := simple.NewUndirectedGraph()
+	for _, p := range [][]int{perm[:20], perm[20:29]} {
+		for i := 1; i < len(p); i++ {
+			g.SetEdge(simple.Edge{F: simple.Node(p[i-1]), T: simple.Node(p[i])})
+		}
+	}
+	g.AddNode(simple.Node(perm[29]))
+
+	got := Seriate(g)
+	if len(got) != 30 {
+		t.Fatalf("unexpected number of nodes: got:%d want:30", len(got))
+	}
+	pos := make(map[int64]int)
+	for i, n := range got {
+		pos[n.ID()] = i
+	}
+	for _, p := range [][]int{perm[:20], perm[20:29]} {
+		for i := 1; i < len(p); i++ {
+			d := pos[int64(p[i])] - pos[int64(p[i-1])]
+			if d != 1 && d != -1 {
+				t.Errorf("path neighbors %d and %d not adjacent in order: %v", p[i-1], p[i], got)
+			}
+		}
+	}
+
+	// The result is deterministic.
+	again := Seriate(g)
+	for i := range got {
+		if got[i].ID() != again[i].ID() {
+			t.Errorf("order not deterministic: got:%v and %v", got, again)
+			break
+		}
+	}
+}
+
+func TestSeriateGrid(t *testing.T) {
+	// A long thin grid is ordered column by column.
+	const rows, cols = 3, 15
+	got := Seriate(grid(rows, cols))
+	for i := rows; i < len(got); i++ {
+		if got[i].ID()%cols < got[i-rows].ID()%cols {
+			t.Errorf("column order not preserved at %d: %v", i, got)
+		}
+	}
+	if first := got[0].ID() % cols; first != 0 {
+		t.Errorf("unexpected orientation: first column got:%d want:0", first)
+	}
+}
+
+func TestSeriateLongPath(t *testing.T) {
+	// The spectral gap of a long path is small,
+	// so this requires a solver that converges
+	// independently of it.
+	const n = 1000
+	perm := rand.New(rand.NewSource(1)).Perm(n)
+	g := simple.NewUndirectedGraph()
+	for i := 1; i < n; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(perm[i-1]), T: simple.Node(perm[i])})
+	}
+	got := Seriate(g)
+	pos := make(map[int64]int)
+	for i, n := range got {
+		pos[n.ID()] = i
+	}
+	var bad int
+	for i := 1; i < n; i++ {
+		d := pos[int64(perm[i])] - pos[int64(perm[i-1])]
+		if d != 1 && d != -1 {
+			bad++
+		}
+	}
+	if bad != 0 {
+		t.Errorf("%d of %d path neighbors not adjacent in order", bad, n-1)
+	}
+}
+
+func TestSeriatePoints(t *testing.T) {
+	// Points along a spiral arm in shuffled order, the arm
+	// turning so that no coordinate orders them, and a
+	// cluster too large to be linked to the arm.
+	const n, k = 200, 4
+	perm := rand.New(rand.NewSource(1)).Perm(n)
+	var pts []Point
+	for _, p := range perm {
+		theta := 3 * math.Pi * float64(p) / n
+		r := 1 + theta
+		pts = append(pts, Point{X: r * math.Cos(theta), Y: r * math.Sin(theta)})
+	}
+	for i := 0; i <= k; i++ {
+		pts = append(pts, Point{X: 100, Y: 100 + 0.1*float64(i)})
+	}
+
+	got := SeriatePoints(pts, k)
+	if len(got) != len(pts) {
+		t.Fatalf("unexpected number of indices: got:%d want:%d", len(got), len(pts))
+	}
+	// The arm is placed first, in order along the arm in either
+	// direction up to small displacements where the ends of the
+	// arm have fewer neighbors.
+	reverse := perm[got[0]] > n/2
+	for i, j := range got[:n] {
+		if j >= n {
+			t.Fatalf("cluster point %d placed within arm at %d", j, i)
+		}
+		want := i
+		if reverse {
+			want = n - 1 - i
+		}
+		if d := perm[j] - want; d < -k || k < d {
+			t.Errorf("arm point %d placed at %d: got:%d want:%d±%d", perm[j], i, perm[j], want, k)
+		}
+	}
+
+	if got := SeriatePoints(pts[:1], k); len(got) != 1 || got[0] != 0 {
+		t.Errorf("unexpected order of single point: got:%v want:[0]", got)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// stressTerm is a term of a stress function attracting
+// a node to the node at index j at the given distance.
+type stressTerm struct {
+	j      int
+	dist   float64
+	weight float64
+}
+
+// SparseStress returns a layout of g refined from init by majorization
+// of a sparse approximation of the stress function
+//
+//	Σ w_ij (|x_i - x_j| - d_ij)²,
+//
+// where d_ij is the distance between nodes i and j computed by d, or the
+// hop distance if d is nil, and w_ij = d_ij⁻².
+//
+// The full stress function has a term for every pair of nodes. The sparse
+// approximation retains the terms for adjacent nodes, using the edge length
+// reported by d as their distance, and replaces the remaining terms of each
+// node by terms to the given number of pivots, each weighted by the number
+// of nodes the pivot represents. This reduces the cost of an iteration
+// from quadratic to O(pivots·n + m) for a graph with n nodes and m edges.
+//
+// If init is nil, the initial layout is the PivotMDS layout of g with the
+// same pivots and Distancer. Otherwise init must hold a position for every
+// node of g and is not modified. Nodes in different connected components
+// do not interact through pivot terms, so components should be laid out
+// separately. SparseStress will panic if pivots is not positive or
+// iterations is negative.
+//
+// References:
+//   - Ortmann, M., Klimenta, M. and Brandes, U. (2016). A sparse stress model.
+//     In Graph Drawing and Network Visualization (pp. 18-32). Springer.
+func SparseStress(g graph.Graph, init Layout, pivots, iterations int, d Distancer) Layout {
+	if pivots <= 0 {
+		panic("layout: number of pivots not positive")
+	}
+	if iterations < 0 {
+		panic("layout: negative number of iterations")
+	}
+	if d == nil {
+		d = Hops{}
+	}
+	nodes := sortedNodes(g)
+	n := len(nodes)
+	if n == 0 {
+		return Layout{}
+	}
+	indexOf := make(map[int64]int, n)
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+
+	// The pivot distances serve both the initial
+	// layout and the pivot terms of the stress.
+	adj := newAdjacency(g, nodes, indexOf, d)
+	dist, pivotIdx, maxDist := maxMinPivots(g, nodes, indexOf, adj, d, pivots)
+	if init == nil {
+		init = pivotMDSLayout(nodes, dist, pivotIdx, maxDist)
+	}
+	pos := make([]Point, n)
+	for i, u := range nodes {
+		p, ok := init[u.ID()]
+		if !ok {
+			panic("layout: missing initial position")
+		}
+		pos[i] = p
+	}
+
+	terms := sparseStressTerms(adj, dist, pivotIdx)
+
+	for it := 0; it < iterations; it++ {
+		for i, ts := range terms {
+			var x, y, wSum float64
+			for _, t := range ts {
+				dx := pos[i].X - pos[t.j].X
+				dy := pos[i].Y - pos[t.j].Y
+				tx, ty := pos[t.j].X, pos[t.j].Y
+				if norm := math.Hypot(dx, dy); norm != 0 {
+					tx += t.dist * dx / norm
+					ty += t.dist * dy / norm
+				}
+				x += t.weight * tx
+				y += t.weight * ty
+				wSum += t.weight
+			}
+			if wSum != 0 {
+				pos[i] = Point{X: x / wSum, Y: y / wSum}
+			}
+		}
+	}
+
+	layout := make(Layout, n)
+	for i, u := range nodes {
+		layout[u.ID()] = pos[i]
+	}
+	return layout
+}
+
+// sparseStressTerms returns the stress terms of each node for the sparse
+// stress model, given the adjacency of the graph with the edge lengths
+// of the Distancer used to compute the row-major distance matrix from
+// each node to each of the pivots.
+func sparseStressTerms(adj *adjacency, dist []float64, pivots []int) [][]stressTerm {
+	n := len(adj.start) - 1
+	k := len(pivots)
+
+	// Assign each node to the region of its closest pivot and
+	// sort the distances from each pivot to its region members.
+	region := make([][]float64, k)
+	for i := 0; i < n; i++ {
+		closest := 0
+		for j := 1; j < k; j++ {
+			if dist[i*k+j] < dist[i*k+closest] {
+				closest = j
+			}
+		}
+		if d := dist[i*k+closest]; !math.IsInf(d, 1) {
+			region[closest] = append(region[closest], d)
+		}
+	}
+	for _, r := range region {
+		sort.Float64s(r)
+	}
+
+	terms := make([][]stressTerm, n)
+	// adjacent[j] is i+1 when node j is a neighbor of node i.
+	adjacent := make([]int, n)
+	for i := 0; i < n; i++ {
+		for e := adj.start[i]; e < adj.start[i+1]; e++ {
+			j, l := adj.to[e], adj.length[e]
+			if j == i || l <= 0 || math.IsInf(l, 1) {
+				continue
+			}
+			adjacent[j] = i + 1
+			terms[i] = append(terms[i], stressTerm{j: j, dist: l, weight: 1 / (l * l)})
+		}
+		for j, p := range pivots {
+			dp := dist[i*k+j]
+			if p == i || adjacent[p] == i+1 || dp <= 0 || math.IsInf(dp, 1) {
+				continue
+			}
+			// The pivot stands in for the members of its
+			// region that are nearer to it than to node i.
+			s := sort.SearchFloat64s(region[j], math.Nextafter(dp/2, math.Inf(1)))
+			if s == 0 {
+				continue
+			}
+			terms[i] = append(terms[i], stressTerm{j: p, dist: dp, weight: float64(s) / (dp * dp)})
+		}
+	}
+	return terms
+}
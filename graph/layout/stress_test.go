@@ -0,0 +1,98 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// stress returns the full stress of the layout l of g.
+func stress(g graph.Graph, l Layout) float64 {
+	nodes := sortedNodes(g)
+	indexOf := make(map[int64]int)
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+	adj := newAdjacency(g, nodes, indexOf, Hops{})
+	d := make([]float64, len(nodes))
+	var s float64
+	for i, u := range nodes {
+		adj.bfs(d, i)
+		for j, v := range nodes[:i] {
+			if math.IsInf(d[j], 1) {
+				continue
+			}
+			e := dist(l[u.ID()], l[v.ID()]) - d[j]
+			s += e * e / (d[j] * d[j])
+		}
+	}
+	return s
+}
+
+func TestSparseStress(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		name   string
+		g      graph.Graph
+		pivots int
+	}{
+		{name: "path", g: path(20), pivots: 4},
+		{name: "grid", g: grid(8, 9), pivots: 10},
+		{name: "grid all pivots", g: grid(5, 5), pivots: 25},
+	} {
+		init := make(Layout)
+		nodes := test.g.Nodes()
+		for nodes.Next() {
+			init[nodes.Node().ID()] = Point{X: 10 * rnd.Float64(), Y: 10 * rnd.Float64()}
+		}
+		orig := make(Layout)
+		for id, p := range init {
+			orig[id] = p
+		}
+
+		before := stress(test.g, init)
+		l := SparseStress(test.g, init, test.pivots, 100, nil)
+		after := stress(test.g, l)
+		if after > before/10 {
+			t.Errorf("insufficient stress reduction for %s: before:%v after:%v", test.name, before, after)
+		}
+		for id, p := range init {
+			if orig[id] != p {
+				t.Errorf("initial layout modified for %s", test.name)
+				break
+			}
+		}
+
+		mds := stress(test.g, PivotMDS(test.g, test.pivots, nil))
+		refined := stress(test.g, SparseStress(test.g, nil, test.pivots, 20, nil))
+		if refined > mds*(1+1e-9) {
+			t.Errorf("refinement increased stress for %s: PivotMDS:%v refined:%v", test.name, mds, refined)
+		}
+	}
+}
+
+func TestSparseStressEmpty(t *testing.T) {
+	l := SparseStress(path(0), nil, 3, 10, nil)
+	if len(l) != 0 {
+		t.Errorf("unexpected layout for empty graph: %v", l)
+	}
+}
+
+func BenchmarkSparseStress(b *testing.B) {
+	for _, size := range []int{30, 300} {
+		g := grid(size, size)
+		b.Run(fmt.Sprintf("grid%dx%d", size, size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				SparseStress(g, nil, 50, 5, nil)
+			}
+		})
+	}
+}
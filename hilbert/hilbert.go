@@ -0,0 +1,157 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hilbert provides linearization of planar point sets along a
+// Hilbert curve.
+//
+// Points that are close along a Hilbert curve are close in the plane, so
+// ordering by curve distance gives cache-friendly processing orders, keys
+// for sharding spatial data and one-dimensional indexes of two-dimensional
+// data.
+//
+// References:
+//   - Hilbert, D. (1891). Über die stetige Abbildung einer Linie auf ein
+//     Flächenstück. Mathematische Annalen, 38(3), 459-460.
+package hilbert
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/exp/spatial/r2"
+)
+
+// MaxOrder is the highest supported curve order.
+const MaxOrder = 31
+
+// Point is a location in the plane.
+type Point = r2.Vec
+
+// Distance returns the distance along the Hilbert curve of the given order
+// to the cell (x, y) of the 2^order×2^order grid the curve fills. The curve
+// starts at cell (0, 0) and ends at cell (2^order-1, 0).
+//
+// Distance will panic if order is greater than MaxOrder or if x or y is
+// outside the grid.
+func Distance(order uint, x, y uint32) uint64 {
+	n := side(order)
+	if uint64(x) >= n || uint64(y) >= n {
+		panic("hilbert: cell outside grid")
+	}
+	var d uint64
+	for s := n / 2; s > 0; s /= 2 {
+		var rx, ry uint64
+		if uint64(x)&s != 0 {
+			rx = 1
+		}
+		if uint64(y)&s != 0 {
+			ry = 1
+		}
+		d += s * s * ((3 * rx) ^ ry)
+		x, y = rotate(n, x, y, rx, ry)
+	}
+	return d
+}
+
+// Cell returns the cell of the 2^order×2^order grid at distance d along
+// the Hilbert curve of the given order. It is the inverse of Distance.
+//
+// Cell will panic if order is greater than MaxOrder or if d is beyond the
+// end of the curve.
+func Cell(order uint, d uint64) (x, y uint32) {
+	n := side(order)
+	if d >= n*n {
+		panic("hilbert: distance beyond end of curve")
+	}
+	for s := uint64(1); s < n; s *= 2 {
+		rx := 1 & (d / 2)
+		ry := 1 & (d ^ rx)
+		x, y = rotate(s, x, y, rx, ry)
+		x += uint32(s * rx)
+		y += uint32(s * ry)
+		d /= 4
+	}
+	return x, y
+}
+
+// side returns the number of cells along each side of the grid
+// filled by a curve of the given order.
+func side(order uint) uint64 {
+	if order > MaxOrder {
+		panic("hilbert: order too high")
+	}
+	return 1 << order
+}
+
+// rotate reflects and transposes the cell (x, y) within a quadrant of
+// side n so that the sub-curve in the quadrant has the orientation of
+// the whole curve.
+func rotate(n uint64, x, y uint32, rx, ry uint64) (uint32, uint32) {
+	if ry == 0 {
+		if rx == 1 {
+			x = uint32(n-1) - x
+			y = uint32(n-1) - y
+		}
+		x, y = y, x
+	}
+	return x, y
+}
+
+// Key is the position of a point along a Hilbert curve.
+type Key struct {
+	// Index is the index of the point in
+	// the linearized point set.
+	Index int
+
+	// D is the distance along the curve of the
+	// grid cell holding the point.
+	D uint64
+
+	// T is D scaled to the interval [0, 1).
+	T float64
+}
+
+// Sort returns the keys of the points in pts sorted by their position
+// along the Hilbert curve of the given order filling the smallest square
+// holding all the points. Points in the same grid cell are ordered by
+// their index in pts.
+//
+// Sort will panic if order is greater than MaxOrder or if any coordinate
+// is not finite.
+func Sort(pts []Point, order uint) []Key {
+	n := side(order)
+	if len(pts) == 0 {
+		return nil
+	}
+	min, max := pts[0], pts[0]
+	for _, p := range pts {
+		if math.IsInf(p.X, 0) || math.IsInf(p.Y, 0) || math.IsNaN(p.X) || math.IsNaN(p.Y) {
+			panic("hilbert: non-finite coordinate")
+		}
+		min.X = math.Min(min.X, p.X)
+		min.Y = math.Min(min.Y, p.Y)
+		max.X = math.Max(max.X, p.X)
+		max.Y = math.Max(max.Y, p.Y)
+	}
+	size := math.Max(max.X-min.X, max.Y-min.Y)
+	if size == 0 {
+		size = 1
+	}
+	scale := float64(n) / size
+	cell := func(v, min float64) uint32 {
+		c := uint64((v - min) * scale)
+		if c >= n {
+			c = n - 1
+		}
+		return uint32(c)
+	}
+
+	keys := make([]Key, len(pts))
+	for i, p := range pts {
+		d := Distance(order, cell(p.X, min.X), cell(p.Y, min.Y))
+		keys[i] = Key{Index: i, D: d, T: float64(d) / float64(n*n)}
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].D < keys[j].D })
+	return keys
+}
@@ -0,0 +1,97 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hilbert
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestDistanceCell(t *testing.T) {
+	// The first order curve.
+	for d, c := range [][2]uint32{{0, 0}, {0, 1}, {1, 1}, {1, 0}} {
+		if got := Distance(1, c[0], c[1]); got != uint64(d) {
+			t.Errorf("unexpected distance to %v: got:%d want:%d", c, got, d)
+		}
+	}
+
+	for _, order := range []uint{0, 1, 2, 3, 5} {
+		n := uint64(1) << order
+		seen := make(map[[2]uint32]bool)
+		var px, py uint32
+		for d := uint64(0); d < n*n; d++ {
+			x, y := Cell(order, d)
+			if seen[[2]uint32{x, y}] {
+				t.Errorf("cell (%d, %d) visited twice for order %d", x, y, order)
+			}
+			seen[[2]uint32{x, y}] = true
+			if got := Distance(order, x, y); got != d {
+				t.Errorf("unexpected distance for order %d to (%d, %d): got:%d want:%d", order, x, y, got, d)
+			}
+			if d != 0 && absDiff(x, px)+absDiff(y, py) != 1 {
+				t.Errorf("cells at %d and %d not adjacent for order %d: (%d, %d) (%d, %d)", d-1, d, order, px, py, x, y)
+			}
+			px, py = x, y
+		}
+		if px != uint32(n-1) || py != 0 {
+			t.Errorf("unexpected end of curve for order %d: got:(%d, %d) want:(%d, 0)", order, px, py, n-1)
+		}
+	}
+
+	const order = MaxOrder
+	for _, d := range []uint64{0, 1, 1 << 40, 1<<62 - 1} {
+		x, y := Cell(order, d)
+		if got := Distance(order, x, y); got != d {
+			t.Errorf("unexpected round trip for maximum order: got:%d want:%d", got, d)
+		}
+	}
+}
+
+func absDiff(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func TestSort(t *testing.T) {
+	// Points at the centers of the cells of an order 3 curve,
+	// shuffled and shifted.
+	const order = 3
+	pts := make([]Point, 64)
+	for d := range pts {
+		x, y := Cell(order, uint64(d))
+		pts[d] = Point{X: 10 + 2*(float64(x)+0.5), Y: -5 + 2*(float64(y)+0.5)}
+	}
+	perm := rand.New(rand.NewSource(1)).Perm(len(pts))
+	shuffled := make([]Point, len(pts))
+	for i, j := range perm {
+		shuffled[j] = pts[i]
+	}
+	// Stretch the bounding box to the full grid.
+	shuffled = append(shuffled, Point{X: 10, Y: -5}, Point{X: 26, Y: 11})
+
+	keys := Sort(shuffled, order)
+	if len(keys) != len(shuffled) {
+		t.Fatalf("unexpected number of keys: got:%d want:%d", len(keys), len(shuffled))
+	}
+	var i int
+	for _, k := range keys {
+		if k.Index >= len(pts) {
+			continue
+		}
+		if want := perm[i]; k.Index != want {
+			t.Errorf("unexpected point at position %d: got:%d want:%d", i, k.Index, want)
+		}
+		if want := float64(i) / 64; k.T != want {
+			t.Errorf("unexpected curve parameter at position %d: got:%v want:%v", i, k.T, want)
+		}
+		i++
+	}
+	if Sort(nil, order) != nil {
+		t.Error("unexpected keys for empty point set")
+	}
+}
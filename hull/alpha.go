@@ -0,0 +1,95 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hull
+
+import (
+	"math"
+	"sort"
+)
+
+// Alpha returns the boundary of the alpha shape of pts as a set of closed
+// polygons. The alpha shape is the union of the Delaunay triangles of pts
+// whose circumradius is at most alpha. As alpha grows the shape approaches
+// the convex hull, and as it shrinks the shape becomes more concave and
+// eventually separates into pieces or vanishes.
+//
+// Outer boundaries are in counter-clockwise order and the boundaries of
+// holes are in clockwise order. Points and edges that are not part of any
+// retained triangle do not contribute to the result. Points that are
+// distinct but very close together are each vertices of the triangulation,
+// so a shape may have very short edges. Alpha will panic if alpha is
+// negative or if a coordinate of a point in pts is not finite.
+//
+// References:
+//   - Edelsbrunner, H., Kirkpatrick, D. and Seidel, R. (1983). On the shape
+//     of a set of points in the plane. IEEE Transactions on Information
+//     Theory, 29(4), 551-559.
+func Alpha(pts []Point, alpha float64) [][]Point {
+	if alpha < 0 {
+		panic("hull: negative alpha")
+	}
+	p := unique(pts)
+
+	// Collect the directed edges of the retained triangles.
+	edges := make(map[edge]bool)
+	for _, t := range delaunay(p) {
+		if circumradius(p[t[0]], p[t[1]], p[t[2]]) > alpha {
+			continue
+		}
+		for k := 0; k < 3; k++ {
+			edges[edge{t[k], t[(k+1)%3]}] = true
+		}
+	}
+
+	// Boundary edges are those without a reversed twin.
+	next := make(map[int][]int)
+	for e := range edges {
+		if !edges[edge{e[1], e[0]}] {
+			next[e[0]] = append(next[e[0]], e[1])
+		}
+	}
+	starts := make([]int, 0, len(next))
+	for v, to := range next {
+		sort.Ints(to)
+		starts = append(starts, v)
+	}
+	sort.Ints(starts)
+
+	// Chain boundary edges into closed polygons. In a valid
+	// triangulation every vertex has as many outgoing boundary
+	// edges as incoming, so each walk returns to its start.
+	var polys [][]Point
+	for _, s := range starts {
+		for len(next[s]) != 0 {
+			var poly []Point
+			for v := s; ; {
+				poly = append(poly, p[v])
+				if len(next[v]) == 0 {
+					panic("hull: open boundary chain")
+				}
+				w := next[v][0]
+				next[v] = next[v][1:]
+				v = w
+				if v == s {
+					break
+				}
+			}
+			polys = append(polys, poly)
+		}
+	}
+	return polys
+}
+
+// circumradius returns the radius of the circle through a, b and c.
+func circumradius(a, b, c Point) float64 {
+	ab := math.Hypot(b.X-a.X, b.Y-a.Y)
+	bc := math.Hypot(c.X-b.X, c.Y-b.Y)
+	ca := math.Hypot(a.X-c.X, a.Y-c.Y)
+	area := math.Abs(cross(a, b, c)) / 2
+	if area == 0 {
+		return math.Inf(1)
+	}
+	return ab * bc * ca / (4 * area)
+}
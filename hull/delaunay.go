@@ -0,0 +1,242 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hull
+
+import (
+	"math"
+
+	"gonum.org/v1/exp/hilbert"
+)
+
+// triangle is a triangle of point indices in counter-clockwise order.
+type triangle [3]int
+
+// edge is a directed edge between point indices.
+type edge [2]int
+
+// delaunay returns the Delaunay triangulation of the distinct points in
+// pts, with each triangle in counter-clockwise order. If all the points
+// are collinear, delaunay returns nil.
+//
+// The triangulation is built by Bowyer-Watson insertion, with each hull
+// edge closed by a ghost triangle to a vertex at infinity. The ghost
+// vertex makes every directed edge belong to exactly one triangle, so the
+// triangulation is stored as a map from each directed edge to the third
+// vertex of its triangle. Points are inserted in Hilbert curve order and
+// each is located by walking from the previously created triangle, so
+// walks are short and the expected running time for evenly spread points
+// is close to linear. The worst-case running time is quadratic. The
+// orientation and incircle predicates are exact, so the triangulation is
+// valid however close together or nearly cocircular the points are.
+func delaunay(pts []Point) []triangle {
+	n := len(pts)
+	ghost := n
+
+	// Find an initial non-degenerate triangle.
+	if n < 3 {
+		return nil
+	}
+	third := -1
+	for i := 2; i < n; i++ {
+		if cross(pts[0], pts[1], pts[i]) != 0 {
+			third = i
+			break
+		}
+	}
+	if third < 0 {
+		return nil
+	}
+	a, b, c := 0, 1, third
+	if cross(pts[a], pts[b], pts[c]) < 0 {
+		a, b = b, a
+	}
+
+	t := make(map[edge]int)
+	add := func(tri triangle) {
+		for k := 0; k < 3; k++ {
+			t[edge{tri[k], tri[(k+1)%3]}] = tri[(k+2)%3]
+		}
+	}
+	remove := func(tri triangle) {
+		for k := 0; k < 3; k++ {
+			delete(t, edge{tri[k], tri[(k+1)%3]})
+		}
+	}
+	add(triangle{a, b, c})
+	add(triangle{b, a, ghost})
+	add(triangle{c, b, ghost})
+	add(triangle{a, c, ghost})
+
+	// contains returns whether p is within the circumcircle of tri. A
+	// ghost triangle's circumcircle is the open half-plane beyond its
+	// real edge together with the interior of that edge.
+	contains := func(tri triangle, p Point) bool {
+		for k := 0; k < 3; k++ {
+			if tri[k] != ghost {
+				continue
+			}
+			u, v := pts[tri[(k+1)%3]], pts[tri[(k+2)%3]]
+			switch o := cross(u, v, p); {
+			case o > 0:
+				return true
+			case o < 0:
+				return false
+			}
+			// p is on the line through u and v, and the
+			// comparison of coordinates is exact.
+			if u.X != v.X {
+				return math.Min(u.X, v.X) < p.X && p.X < math.Max(u.X, v.X)
+			}
+			return math.Min(u.Y, v.Y) < p.Y && p.Y < math.Max(u.Y, v.Y)
+		}
+		return inCircle(pts[tri[0]], pts[tri[1]], pts[tri[2]], p)
+	}
+
+	isGhost := func(tri triangle) bool {
+		return tri[0] == ghost || tri[1] == ghost || tri[2] == ghost
+	}
+
+	// locate returns a triangle whose circumcircle contains p, found by
+	// a visibility walk from the triangle from, or by a search of all
+	// the triangles if the walk does not end within as many steps as
+	// there are edges.
+	locate := func(from triangle, p Point) (triangle, bool) {
+		tri := from
+		for steps := 0; steps < len(t); steps++ {
+			if isGhost(tri) {
+				// The walk has left the hull. Step back
+				// in across the real edge, unless p is
+				// beyond it.
+				if contains(tri, p) {
+					return tri, true
+				}
+				for tri[2] != ghost {
+					tri = triangle{tri[1], tri[2], tri[0]}
+				}
+				tri = triangle{tri[1], tri[0], t[edge{tri[1], tri[0]}]}
+				continue
+			}
+			moved := false
+			for k := 0; k < 3; k++ {
+				e0, e1 := tri[k], tri[(k+1)%3]
+				if cross(pts[e0], pts[e1], p) < 0 {
+					tri = triangle{e1, e0, t[edge{e1, e0}]}
+					moved = true
+					break
+				}
+			}
+			if !moved {
+				if contains(tri, p) {
+					return tri, true
+				}
+				break
+			}
+		}
+
+		// Fall back to the first containing triangle in a
+		// deterministic order.
+		var best triangle
+		found := false
+		for e, w := range t {
+			tri := canonical(triangle{e[0], e[1], w})
+			if !contains(tri, p) {
+				continue
+			}
+			if !found || less(tri, best) {
+				best = tri
+				found = true
+			}
+		}
+		return best, found
+	}
+
+	last := triangle{a, b, c}
+	for _, key := range hilbert.Sort(pts, 16) {
+		i := key.Index
+		if i == a || i == b || i == c {
+			continue
+		}
+		p := pts[i]
+
+		start, found := locate(last, p)
+		if !found {
+			// The predicates are exact, so every point is
+			// either within the hull or beyond a hull edge.
+			panic("hull: point not located")
+		}
+
+		// Grow the cavity of triangles whose circumcircles contain p
+		// outward from the start so that it remains connected.
+		visited := map[triangle]bool{canonical(start): true}
+		stack := []triangle{start}
+		var bad []triangle
+		var boundary []edge
+		for len(stack) != 0 {
+			tri := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			bad = append(bad, tri)
+			for k := 0; k < 3; k++ {
+				e := edge{tri[k], tri[(k+1)%3]}
+				w := t[edge{e[1], e[0]}]
+				nb := triangle{e[1], e[0], w}
+				if visited[canonical(nb)] {
+					continue
+				}
+				// The predicates are exact, so the cavity is
+				// star-shaped from p and each boundary edge
+				// forms a proper triangle with p.
+				if contains(nb, p) {
+					visited[canonical(nb)] = true
+					stack = append(stack, nb)
+				} else {
+					boundary = append(boundary, e)
+				}
+			}
+		}
+		for _, tri := range bad {
+			remove(tri)
+		}
+		for _, e := range boundary {
+			tri := triangle{e[0], e[1], i}
+			add(tri)
+			if !isGhost(tri) {
+				last = tri
+			}
+		}
+	}
+
+	var tris []triangle
+	seen := make(map[triangle]bool)
+	for e, w := range t {
+		tri := canonical(triangle{e[0], e[1], w})
+		if seen[tri] || isGhost(tri) {
+			continue
+		}
+		seen[tri] = true
+		tris = append(tris, tri)
+	}
+	return tris
+}
+
+// canonical returns tri rotated so that its smallest index is first.
+func canonical(tri triangle) triangle {
+	switch {
+	case tri[1] < tri[0] && tri[1] < tri[2]:
+		return triangle{tri[1], tri[2], tri[0]}
+	case tri[2] < tri[0] && tri[2] < tri[1]:
+		return triangle{tri[2], tri[0], tri[1]}
+	}
+	return tri
+}
+
+// less returns whether a sorts before b in lexical order.
+func less(a, b triangle) bool {
+	for k := range a {
+		if a[k] != b[k] {
+			return a[k] < b[k]
+		}
+	}
+	return false
+}
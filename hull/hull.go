@@ -0,0 +1,71 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hull provides convex and concave hulls of planar point sets.
+package hull
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/exp/spatial/r2"
+)
+
+// Point is a location in the plane.
+type Point = r2.Vec
+
+// Convex returns the vertices of the convex hull of pts in
+// counter-clockwise order, starting from the lowest, leftmost point.
+// Points lying on the hull between vertices are not included. If
+// pts holds fewer than three distinct non-collinear points, the
+// distinct extreme points are returned. Convex will panic if a
+// coordinate of a point in pts is not finite.
+func Convex(pts []Point) []Point {
+	p := unique(pts)
+	if len(p) < 3 {
+		return p
+	}
+	// Andrew's monotone chain.
+	sort.Slice(p, func(i, j int) bool {
+		return p[i].Y < p[j].Y || (p[i].Y == p[j].Y && p[i].X < p[j].X)
+	})
+	h := make([]Point, 0, 2*len(p))
+	for _, v := range p {
+		for len(h) >= 2 && cross(h[len(h)-2], h[len(h)-1], v) <= 0 {
+			h = h[:len(h)-1]
+		}
+		h = append(h, v)
+	}
+	lower := len(h) + 1
+	for i := len(p) - 2; i >= 0; i-- {
+		for len(h) >= lower && cross(h[len(h)-2], h[len(h)-1], p[i]) <= 0 {
+			h = h[:len(h)-1]
+		}
+		h = append(h, p[i])
+	}
+	return h[:len(h)-1]
+}
+
+// unique returns a sorted copy of pts with duplicates removed.
+// It will panic if a coordinate of a point in pts is not finite.
+func unique(pts []Point) []Point {
+	p := make([]Point, len(pts))
+	for i, v := range pts {
+		if math.IsNaN(v.X) || math.IsInf(v.X, 0) || math.IsNaN(v.Y) || math.IsInf(v.Y, 0) {
+			panic("hull: non-finite coordinate")
+		}
+		p[i] = v
+	}
+	sort.Slice(p, func(i, j int) bool {
+		return p[i].X < p[j].X || (p[i].X == p[j].X && p[i].Y < p[j].Y)
+	})
+	n := 0
+	for i, v := range p {
+		if i == 0 || v != p[n-1] {
+			p[n] = v
+			n++
+		}
+	}
+	return p[:n]
+}
@@ -0,0 +1,285 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hull
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+// area returns the signed area of the polygon p, positive
+// when p is in counter-clockwise order.
+func area(p []Point) float64 {
+	var a float64
+	for i, v := range p {
+		w := p[(i+1)%len(p)]
+		a += v.X*w.Y - w.X*v.Y
+	}
+	return a / 2
+}
+
+func gridPoints(rows, cols int, dx, dy float64) []Point {
+	var p []Point
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			p = append(p, Point{X: dx + float64(c), Y: dy + float64(r)})
+		}
+	}
+	return p
+}
+
+func randomPoints(n int, src rand.Source) []Point {
+	rnd := rand.New(src)
+	p := make([]Point, n)
+	for i := range p {
+		p[i] = Point{X: rnd.Float64(), Y: rnd.Float64()}
+	}
+	return p
+}
+
+func TestConvex(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		pts  []Point
+		want []Point
+	}{
+		{name: "empty"},
+		{
+			name: "duplicate",
+			pts:  []Point{{X: 1, Y: 1}, {X: 1, Y: 1}},
+			want: []Point{{X: 1, Y: 1}},
+		},
+		{
+			name: "collinear",
+			pts:  []Point{{X: 0, Y: 0}, {X: 2, Y: 2}, {X: 1, Y: 1}},
+			want: []Point{{X: 0, Y: 0}, {X: 2, Y: 2}},
+		},
+		{
+			name: "square",
+			pts:  append(gridPoints(3, 3, 0, 0), Point{X: 0.5, Y: 0.5}),
+			want: []Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}},
+		},
+	} {
+		got := Convex(test.pts)
+		if len(got) != len(test.want) {
+			t.Errorf("unexpected hull for %s: got:%v want:%v", test.name, got, test.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("unexpected hull for %s: got:%v want:%v", test.name, got, test.want)
+				break
+			}
+		}
+	}
+}
+
+func TestDelaunay(t *testing.T) {
+	for seed := uint64(1); seed <= 5; seed++ {
+		pts := unique(randomPoints(100, rand.NewSource(seed)))
+		tris := delaunay(pts)
+		h := len(Convex(pts))
+		if want := 2*len(pts) - 2 - h; len(tris) != want {
+			t.Errorf("unexpected number of triangles for seed %d: got:%d want:%d", seed, len(tris), want)
+		}
+		var sum float64
+		for _, tr := range tris {
+			a, b, c := pts[tr[0]], pts[tr[1]], pts[tr[2]]
+			if cross(a, b, c) <= 0 {
+				t.Errorf("triangle not counter-clockwise for seed %d: %v", seed, tr)
+			}
+			sum += cross(a, b, c) / 2
+			for i, p := range pts {
+				if i == tr[0] || i == tr[1] || i == tr[2] {
+					continue
+				}
+				if inCircle(a, b, c, p) {
+					t.Errorf("point %d inside circumcircle of %v for seed %d", i, tr, seed)
+				}
+			}
+		}
+		if want := area(Convex(pts)); math.Abs(sum-want) > 1e-12 {
+			t.Errorf("unexpected triangulation area for seed %d: got:%v want:%v", seed, sum, want)
+		}
+	}
+}
+
+func TestDelaunayLarge(t *testing.T) {
+	// Point location walks from the last created triangle, so large
+	// inputs, including cocircular grids, must triangulate quickly.
+	random := unique(randomPoints(20000, rand.NewSource(1)))
+	grid := unique(gridPoints(100, 100, 0, 0))
+	for _, test := range []struct {
+		pts  []Point
+		want int
+	}{
+		{pts: random, want: 2*len(random) - 2 - len(Convex(random))},
+		{pts: grid, want: 2 * 99 * 99},
+	} {
+		tris := delaunay(test.pts)
+		if len(tris) != test.want {
+			t.Errorf("unexpected number of triangles for %d points: got:%d want:%d", len(test.pts), len(tris), test.want)
+		}
+	}
+}
+
+func TestAlpha(t *testing.T) {
+	annulus := func() []Point {
+		var p []Point
+		for _, r := range []float64{4, 5} {
+			for i := 0; i < 40; i++ {
+				theta := 2 * math.Pi * float64(i) / 40
+				p = append(p, Point{X: r * math.Cos(theta), Y: r * math.Sin(theta)})
+			}
+		}
+		return p
+	}()
+	for _, test := range []struct {
+		name  string
+		pts   []Point
+		alpha float64
+		areas []float64
+		sizes []int
+	}{
+		{
+			name:  "grid",
+			pts:   gridPoints(5, 5, 0, 0),
+			alpha: 0.8,
+			areas: []float64{16},
+			sizes: []int{16},
+		},
+		{
+			name:  "grid too small",
+			pts:   gridPoints(5, 5, 0, 0),
+			alpha: 0.6,
+		},
+		{
+			name:  "clusters",
+			pts:   append(gridPoints(2, 3, 0, 0), gridPoints(3, 2, 10, 10)...),
+			alpha: 1,
+			areas: []float64{2, 2},
+			sizes: []int{6, 6},
+		},
+		{
+			name:  "annulus",
+			pts:   annulus,
+			alpha: 1,
+			areas: []float64{area(annulus[40:]), -area(annulus[:40])},
+			sizes: []int{40, 40},
+		},
+	} {
+		got := Alpha(test.pts, test.alpha)
+		if len(got) != len(test.areas) {
+			t.Errorf("unexpected number of polygons for %s: got:%d want:%d", test.name, len(got), len(test.areas))
+			continue
+		}
+		// Match polygons by area since their order is unspecified.
+		used := make([]bool, len(got))
+		for i, want := range test.areas {
+			found := false
+			for j, p := range got {
+				if !used[j] && math.Abs(area(p)-want) < 1e-9 && len(p) == test.sizes[i] {
+					used[j] = true
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("missing polygon for %s with area %v and %d vertices", test.name, want, test.sizes[i])
+			}
+		}
+	}
+}
+
+// nearDuplicates returns pts with copies of its first n points
+// displaced by about 1e-15.
+func nearDuplicates(pts []Point, n int, src rand.Source) []Point {
+	rnd := rand.New(src)
+	p := append([]Point(nil), pts...)
+	for _, v := range pts[:n] {
+		p = append(p, Point{X: v.X + 1e-15*(rnd.Float64()-0.5), Y: v.Y + 1e-15*(rnd.Float64()-0.5)})
+	}
+	return p
+}
+
+func TestDelaunayNearDuplicates(t *testing.T) {
+	for seed := uint64(1); seed <= 40; seed++ {
+		pts := unique(nearDuplicates(randomPoints(100, rand.NewSource(seed)), 50, rand.NewSource(seed+1000)))
+		tris := delaunay(pts)
+		if want := 2*len(pts) - 2 - len(Convex(pts)); len(tris) != want {
+			t.Errorf("unexpected number of triangles for seed %d: got:%d want:%d", seed, len(tris), want)
+		}
+		var sum float64
+		for _, tr := range tris {
+			if a := cross(pts[tr[0]], pts[tr[1]], pts[tr[2]]); a > 0 {
+				sum += a / 2
+			} else {
+				t.Errorf("triangle not counter-clockwise for seed %d: %v", seed, tr)
+			}
+		}
+		if want := area(Convex(pts)); math.Abs(sum-want) > 1e-12 {
+			t.Errorf("unexpected triangulation area for seed %d: got:%v want:%v", seed, sum, want)
+		}
+	}
+}
+
+func TestAlphaNearDuplicates(t *testing.T) {
+	var circle []Point
+	for i := 0; i < 64; i++ {
+		theta := 2 * math.Pi * float64(i) / 64
+		p := Point{X: math.Cos(theta), Y: math.Sin(theta)}
+		circle = append(circle, p, Point{X: p.X + 1e-15, Y: p.Y - 1e-15})
+	}
+	for _, test := range []struct {
+		name string
+		pts  []Point
+	}{
+		{name: "circle", pts: circle},
+		{name: "random", pts: nearDuplicates(randomPoints(100, rand.NewSource(1)), 100, rand.NewSource(2))},
+	} {
+		got := Alpha(test.pts, math.Inf(1))
+		if len(got) != 1 {
+			t.Errorf("unexpected number of polygons for %s: got:%d want:1", test.name, len(got))
+			continue
+		}
+		if a, want := area(got[0]), area(Convex(test.pts)); math.Abs(a-want) > 1e-12 {
+			t.Errorf("unexpected area for %s: got:%v want:%v", test.name, a, want)
+		}
+	}
+}
+
+func TestNonFinite(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		fn   func([]Point)
+	}{
+		{name: "Convex", fn: func(p []Point) { Convex(p) }},
+		{name: "Alpha", fn: func(p []Point) { Alpha(p, 1) }},
+	} {
+		for _, bad := range []Point{{X: math.NaN()}, {Y: math.Inf(1)}, {X: math.Inf(-1)}} {
+			func() {
+				defer func() {
+					if r := recover(); r != "hull: non-finite coordinate" {
+						t.Errorf("unexpected panic for %s with %v: got:%v want:%q", test.name, bad, r, "hull: non-finite coordinate")
+					}
+				}()
+				test.fn([]Point{{X: 0, Y: 0}, {X: 1, Y: 0}, bad, {X: 0, Y: 1}})
+			}()
+		}
+	}
+}
+
+func TestAlphaConvex(t *testing.T) {
+	pts := randomPoints(200, rand.NewSource(1))
+	got := Alpha(pts, math.Inf(1))
+	if len(got) != 1 {
+		t.Fatalf("unexpected number of polygons: got:%d want:1", len(got))
+	}
+	if a, want := area(got[0]), area(Convex(pts)); math.Abs(a-want) > 1e-12 {
+		t.Errorf("unexpected area for infinite alpha: got:%v want:%v", a, want)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hull
+
+import (
+	"math"
+	"math/big"
+)
+
+// The geometric predicates are evaluated in floating point and checked
+// against a bound on the rounding error of the evaluation. Only when the
+// sign of the result is in doubt is the predicate evaluated again in
+// exact rational arithmetic, so that every decision about the position
+// of a point is consistent with every other.
+//
+// References:
+//   - Shewchuk, J. R. (1997). Adaptive precision floating-point arithmetic
+//     and fast robust geometric predicates. Discrete & Computational
+//     Geometry, 18(3), 305-363.
+
+const (
+	epsilon = 1.0 / (1 << 53)
+
+	orientErrBound   = (3 + 16*epsilon) * epsilon
+	inCircleErrBound = (10 + 96*epsilon) * epsilon
+)
+
+// cross returns the z component of the cross product of b-a and c-a.
+// It is positive if a, b and c are in counter-clockwise order. The sign
+// of the result is exact.
+func cross(a, b, c Point) float64 {
+	left := (b.X - a.X) * (c.Y - a.Y)
+	right := (b.Y - a.Y) * (c.X - a.X)
+	det := left - right
+	if math.Abs(det) > orientErrBound*(math.Abs(left)+math.Abs(right)) {
+		return det
+	}
+	return exactFloat(exactCross(a, b, c))
+}
+
+// exactCross returns the cross product of b-a and c-a in exact arithmetic.
+func exactCross(a, b, c Point) *big.Rat {
+	ax, ay := rat(a.X), rat(a.Y)
+	bx := new(big.Rat).Sub(rat(b.X), ax)
+	by := new(big.Rat).Sub(rat(b.Y), ay)
+	cx := new(big.Rat).Sub(rat(c.X), ax)
+	cy := new(big.Rat).Sub(rat(c.Y), ay)
+	bx.Mul(bx, cy)
+	by.Mul(by, cx)
+	return bx.Sub(bx, by)
+}
+
+// inCircle returns whether d lies strictly inside the circumcircle of
+// the counter-clockwise triangle a, b, c.
+func inCircle(a, b, c, d Point) bool {
+	adx, ady := a.X-d.X, a.Y-d.Y
+	bdx, bdy := b.X-d.X, b.Y-d.Y
+	cdx, cdy := c.X-d.X, c.Y-d.Y
+
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+	alift := adx*adx + ady*ady
+	blift := bdx*bdx + bdy*bdy
+	clift := cdx*cdx + cdy*cdy
+
+	det := alift*(bdxcdy-cdxbdy) + blift*(cdxady-adxcdy) + clift*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*alift +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*blift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*clift
+	if math.Abs(det) > inCircleErrBound*permanent {
+		return det > 0
+	}
+	return exactInCircle(a, b, c, d).Sign() > 0
+}
+
+// exactInCircle returns the incircle determinant of a, b, c and d
+// in exact arithmetic.
+func exactInCircle(a, b, c, d Point) *big.Rat {
+	dx, dy := rat(d.X), rat(d.Y)
+	var diff [3][2]*big.Rat
+	for i, p := range [3]Point{a, b, c} {
+		diff[i][0] = new(big.Rat).Sub(rat(p.X), dx)
+		diff[i][1] = new(big.Rat).Sub(rat(p.Y), dy)
+	}
+	det := new(big.Rat)
+	tmp := new(big.Rat)
+	for i := 0; i < 3; i++ {
+		p, q, r := diff[i], diff[(i+1)%3], diff[(i+2)%3]
+		lift := new(big.Rat).Mul(p[0], p[0])
+		lift.Add(lift, tmp.Mul(p[1], p[1]))
+		minor := new(big.Rat).Mul(q[0], r[1])
+		minor.Sub(minor, tmp.Mul(r[0], q[1]))
+		det.Add(det, lift.Mul(lift, minor))
+	}
+	return det
+}
+
+// rat returns x as an exact rational.
+func rat(x float64) *big.Rat {
+	return new(big.Rat).SetFloat64(x)
+}
+
+// exactFloat returns the float64 nearest to x, or the smallest
+// float64 of the same sign if x is non-zero and nearest to zero.
+func exactFloat(x *big.Rat) float64 {
+	f, _ := x.Float64()
+	if f == 0 && x.Sign() != 0 {
+		return float64(x.Sign()) * math.SmallestNonzeroFloat64
+	}
+	return f
+}
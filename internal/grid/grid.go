@@ -0,0 +1,149 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package grid provides a uniform bucket grid over points in the plane
+// for fixed radius and nearest neighbor queries.
+package grid
+
+import (
+	"math"
+
+	"gonum.org/v1/exp/spatial/r2"
+)
+
+// Point is a location in the plane.
+type Point = r2.Vec
+
+// Grid is a uniform bucket grid over a point set.
+type Grid struct {
+	pts   []Point
+	min   Point
+	size  float64
+	cols  int
+	rows  int
+	cells [][]int
+}
+
+// New returns a grid over pts with cells at least size wide. The cell
+// size is increased if needed so that the number of cells is not much
+// larger than the number of points. The grid retains pts, which must
+// not be modified while the grid is in use.
+func New(pts []Point, size float64) *Grid {
+	g := &Grid{pts: pts, size: size, cols: 1, rows: 1}
+	if len(pts) == 0 {
+		g.cells = make([][]int, 1)
+		return g
+	}
+	min, max := pts[0], pts[0]
+	for _, p := range pts[1:] {
+		min.X = math.Min(min.X, p.X)
+		min.Y = math.Min(min.Y, p.Y)
+		max.X = math.Max(max.X, p.X)
+		max.Y = math.Max(max.Y, p.Y)
+	}
+	w, h := max.X-min.X, max.Y-min.Y
+	n := float64(len(pts))
+	g.size = math.Max(g.size, math.Max(math.Sqrt(w*h/n), math.Max(w, h)/n))
+	if g.size == 0 {
+		g.size = 1
+	}
+	g.min = min
+	g.cols = int(w/g.size) + 1
+	g.rows = int(h/g.size) + 1
+	g.cells = make([][]int, g.cols*g.rows)
+	for i, p := range pts {
+		cx, cy := g.cell(p)
+		g.cells[cy*g.cols+cx] = append(g.cells[cy*g.cols+cx], i)
+	}
+	return g
+}
+
+// cell returns the cell holding p, or the closest
+// cell if p is outside the grid.
+func (g *Grid) cell(p Point) (cx, cy int) {
+	cx = clamp(int((p.X-g.min.X)/g.size), g.cols)
+	cy = clamp(int((p.Y-g.min.Y)/g.size), g.rows)
+	return cx, cy
+}
+
+func clamp(c, n int) int {
+	if c < 0 {
+		return 0
+	}
+	if c >= n {
+		return n - 1
+	}
+	return c
+}
+
+// Pairs calls fn once for each unordered pair of distinct points i < j
+// in g that are no more than r apart, with their separation d.
+func (g *Grid) Pairs(r float64, fn func(i, j int, d float64)) {
+	k := g.cols + g.rows
+	if c := math.Ceil(r / g.size); c < float64(k) {
+		k = int(c)
+	}
+	for i, p := range g.pts {
+		cx, cy := g.cell(p)
+		for y := cy - k; y <= cy+k; y++ {
+			if y < 0 || y >= g.rows {
+				continue
+			}
+			for x := cx - k; x <= cx+k; x++ {
+				if x < 0 || x >= g.cols {
+					continue
+				}
+				for _, j := range g.cells[y*g.cols+x] {
+					if j <= i {
+						continue
+					}
+					if d := math.Hypot(g.pts[j].X-p.X, g.pts[j].Y-p.Y); d <= r {
+						fn(i, j, d)
+					}
+				}
+			}
+		}
+	}
+}
+
+// Nearest returns the index of the point in g closest to p, other than
+// the point with index skip, and its distance. Passing a negative skip
+// considers all points. Square rings of cells are searched outward from
+// the cell closest to p until no unsearched cell can hold a closer point.
+// If there is no candidate point, Nearest returns -1 and +Inf.
+func (g *Grid) Nearest(p Point, skip int) (int, float64) {
+	cx, cy := g.cell(p)
+	best := -1
+	bestDist := math.Inf(1)
+	for r := 0; r <= g.cols || r <= g.rows; r++ {
+		for y := cy - r; y <= cy+r; y++ {
+			if y < 0 || y >= g.rows {
+				continue
+			}
+			for x := cx - r; x <= cx+r; x++ {
+				if x < 0 || x >= g.cols {
+					continue
+				}
+				if y != cy-r && y != cy+r && x != cx-r && x != cx+r {
+					// Only visit the ring.
+					continue
+				}
+				for _, j := range g.cells[y*g.cols+x] {
+					if j == skip {
+						continue
+					}
+					if d := math.Hypot(g.pts[j].X-p.X, g.pts[j].Y-p.Y); d < bestDist {
+						best, bestDist = j, d
+					}
+				}
+			}
+		}
+		// All points closer than r cell widths
+		// have now been visited.
+		if best >= 0 && bestDist <= float64(r)*g.size {
+			break
+		}
+	}
+	return best, bestDist
+}
@@ -0,0 +1,71 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grid
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestNearest(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	pts := make([]Point, 37)
+	for i := range pts {
+		pts[i] = Point{X: rnd.Float64() * 100, Y: rnd.Float64() * 50}
+	}
+	g := New(pts, 0)
+	for i := 0; i < 1000; i++ {
+		// Include queries outside the bounds of the points.
+		p := Point{X: rnd.Float64()*120 - 10, Y: rnd.Float64()*70 - 10}
+		skip := rnd.Intn(len(pts)+1) - 1
+		want := -1
+		wantDist := math.Inf(1)
+		for j, q := range pts {
+			if d := math.Hypot(q.X-p.X, q.Y-p.Y); j != skip && d < wantDist {
+				want, wantDist = j, d
+			}
+		}
+		got, gotDist := g.Nearest(p, skip)
+		if got != want || math.Abs(gotDist-wantDist) > 1e-12 {
+			t.Errorf("unexpected nearest point to %v skipping %d: got:%d,%v want:%d,%v", p, skip, got, gotDist, want, wantDist)
+		}
+	}
+
+	if got, d := New([]Point{{X: 1, Y: 1}}, 0).Nearest(Point{X: 1, Y: 1}, 0); got != -1 || !math.IsInf(d, 1) {
+		t.Errorf("unexpected nearest point with no candidates: got:%d,%v want:-1,+Inf", got, d)
+	}
+}
+
+func TestPairs(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	pts := make([]Point, 200)
+	for i := range pts {
+		pts[i] = Point{X: rnd.Float64(), Y: rnd.Float64()}
+	}
+	const r = 0.1
+	var want int
+	for i, p := range pts {
+		for _, q := range pts[i+1:] {
+			if math.Hypot(q.X-p.X, q.Y-p.Y) <= r {
+				want++
+			}
+		}
+	}
+	var got int
+	New(pts, r).Pairs(r, func(i, j int, d float64) {
+		if i >= j {
+			t.Errorf("pair not ordered: %d %d", i, j)
+		}
+		if e := math.Hypot(pts[j].X-pts[i].X, pts[j].Y-pts[i].Y); d != e || d > r {
+			t.Errorf("unexpected separation of %d and %d: got:%v want:%v", i, j, d, e)
+		}
+		got++
+	})
+	if got != want {
+		t.Errorf("unexpected number of pairs: got:%d want:%d", got, want)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pic provides the particle-grid transfers of particle-in-cell
+// methods.
+//
+// Charge is deposited from particles onto the nodes of a uniform grid,
+// where fields may be found by a grid solver, and fields are interpolated
+// from the grid back to the particles. Both transfers use the same
+// cloud-in-cell (bilinear) weights, so a particle exerts no net force on
+// itself.
+//
+// References:
+//   - Birdsall, C. K. and Langdon, A. B. (1991). Plasma Physics via
+//     Computer Simulation. IOP Publishing.
+package pic
+
+import (
+	"math"
+
+	"gonum.org/v1/exp/spatial/r2"
+)
+
+// Point is a location in the plane.
+type Point = r2.Vec
+
+// Grid is a uniform two-dimensional grid of node values.
+type Grid struct {
+	// Min is the location of node (0, 0).
+	Min Point
+
+	// DX and DY are the node spacings.
+	DX, DY float64
+
+	// Cols and Rows are the numbers of nodes
+	// along the x and y axes.
+	Cols, Rows int
+
+	// Periodic specifies that the grid wraps so
+	// that node Cols is node 0 and node Rows is
+	// node 0, giving a period of Cols*DX along x
+	// and Rows*DY along y.
+	Periodic bool
+
+	// Data holds the node values in row-major
+	// order, with node (i, j) at j*Cols+i.
+	Data []float64
+}
+
+// NewGrid returns a grid of cols×rows nodes with node (0, 0) at min and
+// node spacings dx and dy.
+//
+// NewGrid will panic if cols or rows is less than two or if dx or dy is
+// not positive.
+func NewGrid(min Point, dx, dy float64, cols, rows int, periodic bool) *Grid {
+	if cols < 2 || rows < 2 {
+		panic("pic: too few nodes")
+	}
+	if !(dx > 0 && dy > 0) {
+		panic("pic: non-positive node spacing")
+	}
+	return &Grid{
+		Min: min, DX: dx, DY: dy,
+		Cols: cols, Rows: rows,
+		Periodic: periodic,
+		Data:     make([]float64, cols*rows),
+	}
+}
+
+// At returns the value of node (i, j).
+func (g *Grid) At(i, j int) float64 {
+	return g.Data[j*g.Cols+i]
+}
+
+// Reset sets all node values to zero.
+func (g *Grid) Reset() {
+	for i := range g.Data {
+		g.Data[i] = 0
+	}
+}
+
+// Deposit adds the charge density of particles at pos with the given
+// charges to the grid. Each charge is shared between the four nodes of
+// the cell holding the particle in proportion to the area of the opposite
+// sub-rectangle, and is divided by the cell area.
+//
+// Deposit will panic if pos and charge have different lengths, or if the
+// grid is not periodic and a particle lies outside it.
+func (g *Grid) Deposit(pos []Point, charge []float64) {
+	if len(pos) != len(charge) {
+		panic("pic: length mismatch")
+	}
+	area := g.DX * g.DY
+	for k, p := range pos {
+		i0, j0, i1, j1, fx, fy := g.cell(p)
+		q := charge[k] / area
+		g.Data[j0*g.Cols+i0] += q * (1 - fx) * (1 - fy)
+		g.Data[j0*g.Cols+i1] += q * fx * (1 - fy)
+		g.Data[j1*g.Cols+i0] += q * (1 - fx) * fy
+		g.Data[j1*g.Cols+i1] += q * fx * fy
+	}
+}
+
+// Interpolate returns the grid values bilinearly interpolated at each of
+// the particle positions in pos. If dst is not nil, the values are stored
+// in dst and dst is returned.
+//
+// Interpolate will panic if dst is not nil and has a length different to
+// pos, or if the grid is not periodic and a particle lies outside it.
+func (g *Grid) Interpolate(dst []float64, pos []Point) []float64 {
+	if dst == nil {
+		dst = make([]float64, len(pos))
+	}
+	if len(dst) != len(pos) {
+		panic("pic: length mismatch")
+	}
+	for k, p := range pos {
+		i0, j0, i1, j1, fx, fy := g.cell(p)
+		dst[k] = g.Data[j0*g.Cols+i0]*(1-fx)*(1-fy) +
+			g.Data[j0*g.Cols+i1]*fx*(1-fy) +
+			g.Data[j1*g.Cols+i0]*(1-fx)*fy +
+			g.Data[j1*g.Cols+i1]*fx*fy
+	}
+	return dst
+}
+
+// cell returns the indices of the nodes at the corners of the cell
+// holding p and the fractional position of p within the cell.
+func (g *Grid) cell(p Point) (i0, j0, i1, j1 int, fx, fy float64) {
+	i0, i1, fx = g.axis(p.X-g.Min.X, g.DX, g.Cols)
+	j0, j1, fy = g.axis(p.Y-g.Min.Y, g.DY, g.Rows)
+	return i0, j0, i1, j1, fx, fy
+}
+
+// axis returns the indices of the nodes either side of the offset x
+// along an axis of n nodes with spacing d, and the fractional position
+// of x between them.
+func (g *Grid) axis(x, d float64, n int) (lo, hi int, f float64) {
+	u := x / d
+	if g.Periodic {
+		u -= float64(n) * math.Floor(u/float64(n))
+		lo = int(u)
+		f = u - float64(lo)
+		if lo >= n {
+			// u rounded up to the period.
+			lo, f = 0, 0
+		}
+		return lo, (lo + 1) % n, f
+	}
+	if !(0 <= u && u <= float64(n-1)) {
+		panic("pic: particle outside grid")
+	}
+	lo = int(u)
+	if lo == n-1 {
+		lo--
+	}
+	return lo, lo + 1, u - float64(lo)
+}
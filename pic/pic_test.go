@@ -0,0 +1,101 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pic
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestDeposit(t *testing.T) {
+	for _, test := range []struct {
+		periodic bool
+		pos      Point
+		want     map[[2]int]float64
+	}{
+		{
+			pos:  Point{X: 1, Y: 3},
+			want: map[[2]int]float64{{1, 1}: 4},
+		},
+		{
+			pos:  Point{X: 1.25, Y: 4},
+			want: map[[2]int]float64{{1, 1}: 1.5, {2, 1}: 0.5, {1, 2}: 1.5, {2, 2}: 0.5},
+		},
+		{
+			pos:  Point{X: 4, Y: 7},
+			want: map[[2]int]float64{{4, 3}: 4},
+		},
+		{
+			periodic: true,
+			pos:      Point{X: 4.5, Y: 0},
+			want:     map[[2]int]float64{{4, 3}: 1, {0, 3}: 1, {4, 0}: 1, {0, 0}: 1},
+		},
+		{
+			periodic: true,
+			pos:      Point{X: -10, Y: 11},
+			want:     map[[2]int]float64{{0, 1}: 4},
+		},
+	} {
+		// Node (i, j) is at (i, 2j+1).
+		g := NewGrid(Point{Y: 1}, 1, 2, 5, 4, test.periodic)
+		g.Deposit([]Point{test.pos}, []float64{8})
+		for j := 0; j < g.Rows; j++ {
+			for i := 0; i < g.Cols; i++ {
+				if got, want := g.At(i, j), test.want[[2]int{i, j}]; math.Abs(got-want) > 1e-14 {
+					t.Errorf("unexpected density at node (%d, %d) for %v periodic=%t: got:%v want:%v",
+						i, j, test.pos, test.periodic, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	// Bilinear interpolation is exact for a bilinear field.
+	f := func(p Point) float64 { return 1 + 2*p.X - 3*p.Y + 0.5*p.X*p.Y }
+	g := NewGrid(Point{X: -1, Y: -2}, 0.5, 0.25, 9, 17, false)
+	for j := 0; j < g.Rows; j++ {
+		for i := 0; i < g.Cols; i++ {
+			g.Data[j*g.Cols+i] = f(Point{X: g.Min.X + float64(i)*g.DX, Y: g.Min.Y + float64(j)*g.DY})
+		}
+	}
+	rnd := rand.New(rand.NewSource(1))
+	pos := make([]Point, 100)
+	for i := range pos {
+		pos[i] = Point{X: -1 + 4*rnd.Float64(), Y: -2 + 4*rnd.Float64()}
+	}
+	pos = append(pos, Point{X: 3, Y: 2})
+	got := g.Interpolate(nil, pos)
+	for i, p := range pos {
+		if want := f(p); math.Abs(got[i]-want) > 1e-12 {
+			t.Errorf("unexpected value at %v: got:%v want:%v", p, got[i], want)
+		}
+	}
+}
+
+func TestDepositConservation(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, periodic := range []bool{false, true} {
+		g := NewGrid(Point{}, 0.1, 0.2, 11, 6, periodic)
+		pos := make([]Point, 1000)
+		charge := make([]float64, len(pos))
+		var total float64
+		for i := range pos {
+			pos[i] = Point{X: rnd.Float64(), Y: rnd.Float64()}
+			charge[i] = rnd.NormFloat64()
+			total += charge[i]
+		}
+		g.Deposit(pos, charge)
+		var sum float64
+		for _, v := range g.Data {
+			sum += v * g.DX * g.DY
+		}
+		if math.Abs(sum-total) > 1e-10 {
+			t.Errorf("charge not conserved for periodic=%t: got:%v want:%v", periodic, sum, total)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pointcloud
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// WriteOBJ writes the points in p to w as Wavefront OBJ vertices.
+// A point element referring to every vertex is written after the
+// vertex list so that importers retain the points as geometry.
+//
+// If p is a Colorer, vertex colors are appended to each vertex
+// record as red, green and blue values in [0, 1]. This is a widely
+// supported extension of the OBJ format. Alpha is not written.
+func WriteOBJ(w io.Writer, p plotter.XYZer) error {
+	colors, hasColor := p.(Colorer)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, "# gonum.org/v1/exp/pointcloud\n")
+	for i := 0; i < p.Len(); i++ {
+		x, y, z := p.XYZ(i)
+		fmt.Fprintf(bw, "v %v %v %v", x, y, z)
+		if hasColor {
+			r, g, b, _ := rgba8(colors.Color(i))
+			fmt.Fprintf(bw, " %.6g %.6g %.6g", float64(r)/255, float64(g)/255, float64(b)/255)
+		}
+		bw.WriteByte('\n')
+	}
+	if p.Len() != 0 {
+		fmt.Fprint(bw, "p")
+		for i := 1; i <= p.Len(); i++ {
+			fmt.Fprintf(bw, " %d", i)
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,103 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pointcloud
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// PLYFormat specifies the encoding of PLY element data.
+type PLYFormat int
+
+const (
+	// ASCII specifies a human-readable PLY file.
+	ASCII PLYFormat = iota
+	// BinaryLittleEndian specifies a little-endian binary PLY file.
+	BinaryLittleEndian
+	// BinaryBigEndian specifies a big-endian binary PLY file.
+	BinaryBigEndian
+)
+
+func (f PLYFormat) String() string {
+	switch f {
+	case ASCII:
+		return "ascii"
+	case BinaryLittleEndian:
+		return "binary_little_endian"
+	case BinaryBigEndian:
+		return "binary_big_endian"
+	default:
+		return fmt.Sprintf("PLYFormat(%d)", int(f))
+	}
+}
+
+// WritePLY writes the points in p to w as a PLY vertex list in the
+// given format. Coordinates are written as double precision values.
+//
+// If p is a Colorer, each vertex is given red, green, blue and alpha
+// properties, and if p is a plotter.Valuer, each vertex is given a
+// double precision value property.
+func WritePLY(w io.Writer, p plotter.XYZer, format PLYFormat) error {
+	var order binary.ByteOrder
+	switch format {
+	case ASCII:
+	case BinaryLittleEndian:
+		order = binary.LittleEndian
+	case BinaryBigEndian:
+		order = binary.BigEndian
+	default:
+		return fmt.Errorf("pointcloud: unknown PLY format: %v", format)
+	}
+	colors, hasColor := p.(Colorer)
+	values, hasValue := p.(plotter.Valuer)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "ply\nformat %v 1.0\ncomment gonum.org/v1/exp/pointcloud\n", format)
+	fmt.Fprintf(bw, "element vertex %d\n", p.Len())
+	fmt.Fprint(bw, "property double x\nproperty double y\nproperty double z\n")
+	if hasColor {
+		fmt.Fprint(bw, "property uchar red\nproperty uchar green\nproperty uchar blue\nproperty uchar alpha\n")
+	}
+	if hasValue {
+		fmt.Fprint(bw, "property double value\n")
+	}
+	fmt.Fprint(bw, "end_header\n")
+
+	var buf [8]byte
+	for i := 0; i < p.Len(); i++ {
+		x, y, z := p.XYZ(i)
+		if order == nil {
+			fmt.Fprintf(bw, "%v %v %v", x, y, z)
+			if hasColor {
+				r, g, b, a := rgba8(colors.Color(i))
+				fmt.Fprintf(bw, " %d %d %d %d", r, g, b, a)
+			}
+			if hasValue {
+				fmt.Fprintf(bw, " %v", values.Value(i))
+			}
+			bw.WriteByte('\n')
+			continue
+		}
+		for _, v := range [3]float64{x, y, z} {
+			order.PutUint64(buf[:], math.Float64bits(v))
+			bw.Write(buf[:])
+		}
+		if hasColor {
+			r, g, b, a := rgba8(colors.Color(i))
+			bw.Write([]byte{r, g, b, a})
+		}
+		if hasValue {
+			order.PutUint64(buf[:], math.Float64bits(values.Value(i)))
+			bw.Write(buf[:])
+		}
+	}
+	return bw.Flush()
+}
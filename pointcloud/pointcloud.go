@@ -0,0 +1,27 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pointcloud provides writers for point cloud file formats
+// that can be read by mesh viewers such as MeshLab and Blender.
+package pointcloud
+
+import (
+	"image/color"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// Colorer is a plotter.XYZer that provides a color for each point.
+type Colorer interface {
+	plotter.XYZer
+
+	// Color returns the color of the ith point.
+	Color(int) color.Color
+}
+
+// rgba8 returns the non-alpha-premultiplied 8-bit components of c.
+func rgba8(c color.Color) (r, g, b, a uint8) {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return n.R, n.G, n.B, n.A
+}
@@ -0,0 +1,182 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pointcloud
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+type colorValued struct {
+	plotter.XYZs
+	colors []color.Color
+	values []float64
+}
+
+func (p colorValued) Color(i int) color.Color { return p.colors[i] }
+func (p colorValued) Value(i int) float64     { return p.values[i] }
+
+var testPoints = plotter.XYZs{{X: 0, Y: 1, Z: 2}, {X: 0.5, Y: -1, Z: 3}}
+
+func TestWritePLY(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		points plotter.XYZer
+		want   string
+	}{
+		{
+			name:   "plain",
+			points: testPoints,
+			want: `ply
+format ascii 1.0
+comment gonum.org/v1/exp/pointcloud
+element vertex 2
+property double x
+property double y
+property double z
+end_header
+0 1 2
+0.5 -1 3
+`,
+		},
+		{
+			name: "color value",
+			points: colorValued{
+				XYZs:   testPoints,
+				colors: []color.Color{color.NRGBA{R: 255, A: 255}, color.NRGBA{G: 10, B: 20, A: 128}},
+				values: []float64{1.5, -2},
+			},
+			want: `ply
+format ascii 1.0
+comment gonum.org/v1/exp/pointcloud
+element vertex 2
+property double x
+property double y
+property double z
+property uchar red
+property uchar green
+property uchar blue
+property uchar alpha
+property double value
+end_header
+0 1 2 255 0 0 255 1.5
+0.5 -1 3 0 10 20 128 -2
+`,
+		},
+	} {
+		var buf bytes.Buffer
+		err := WritePLY(&buf, test.points, ASCII)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", test.name, err)
+			continue
+		}
+		if got := buf.String(); got != test.want {
+			t.Errorf("unexpected output for %s:\ngot:\n%s\nwant:\n%s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestWritePLYBinary(t *testing.T) {
+	p := colorValued{
+		XYZs:   testPoints,
+		colors: []color.Color{color.NRGBA{R: 255, A: 255}, color.NRGBA{G: 10, B: 20, A: 128}},
+		values: []float64{1.5, -2},
+	}
+	for _, test := range []struct {
+		format PLYFormat
+		order  binary.ByteOrder
+	}{
+		{format: BinaryLittleEndian, order: binary.LittleEndian},
+		{format: BinaryBigEndian, order: binary.BigEndian},
+	} {
+		var buf bytes.Buffer
+		err := WritePLY(&buf, p, test.format)
+		if err != nil {
+			t.Fatalf("unexpected error for %v: %v", test.format, err)
+		}
+		const end = "end_header\n"
+		idx := strings.Index(buf.String(), end)
+		if idx < 0 {
+			t.Fatalf("missing end of header for %v", test.format)
+		}
+		if !strings.Contains(buf.String()[:idx], "format "+test.format.String()+" 1.0\n") {
+			t.Errorf("missing format line for %v", test.format)
+		}
+		data := buf.Bytes()[idx+len(end):]
+		const stride = 3*8 + 4 + 8
+		if len(data) != p.Len()*stride {
+			t.Fatalf("unexpected data length for %v: got:%d want:%d", test.format, len(data), p.Len()*stride)
+		}
+		for i := 0; i < p.Len(); i++ {
+			rec := data[i*stride : (i+1)*stride]
+			x, y, z := p.XYZ(i)
+			for j, want := range []float64{x, y, z} {
+				got := math.Float64frombits(test.order.Uint64(rec[j*8:]))
+				if got != want {
+					t.Errorf("unexpected coordinate %d of point %d for %v: got:%v want:%v", j, i, test.format, got, want)
+				}
+			}
+			r, g, b, a := rgba8(p.Color(i))
+			if !bytes.Equal(rec[24:28], []byte{r, g, b, a}) {
+				t.Errorf("unexpected color of point %d for %v: got:%v want:%v", i, test.format, rec[24:28], []byte{r, g, b, a})
+			}
+			got := math.Float64frombits(test.order.Uint64(rec[28:]))
+			if got != p.Value(i) {
+				t.Errorf("unexpected value of point %d for %v: got:%v want:%v", i, test.format, got, p.Value(i))
+			}
+		}
+	}
+}
+
+func TestWriteOBJ(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		points plotter.XYZer
+		want   string
+	}{
+		{
+			name:   "empty",
+			points: plotter.XYZs{},
+			want:   "# gonum.org/v1/exp/pointcloud\n",
+		},
+		{
+			name:   "plain",
+			points: testPoints,
+			want: `# gonum.org/v1/exp/pointcloud
+v 0 1 2
+v 0.5 -1 3
+p 1 2
+`,
+		},
+		{
+			name: "color",
+			points: colorValued{
+				XYZs:   testPoints,
+				colors: []color.Color{color.NRGBA{R: 255, A: 255}, color.NRGBA{G: 51, B: 102, A: 255}},
+			},
+			want: `# gonum.org/v1/exp/pointcloud
+v 0 1 2 1 0 0
+v 0.5 -1 3 0 0.2 0.4
+p 1 2
+`,
+		},
+	} {
+		var buf bytes.Buffer
+		err := WriteOBJ(&buf, test.points)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", test.name, err)
+			continue
+		}
+		if got := buf.String(); got != test.want {
+			t.Errorf("unexpected output for %s:\ngot:\n%s\nwant:\n%s", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pointpattern
+
+import (
+	"math"
+
+	"gonum.org/v1/exp/internal/grid"
+)
+
+// Neighbor is a weighted neighbor of a point.
+type Neighbor struct {
+	Index  int
+	Weight float64
+}
+
+// Weights is a sparse spatial weights matrix. Element i holds the
+// neighbors of point i and their weights.
+type Weights [][]Neighbor
+
+// DistanceBand returns spatial weights linking each pair of points in pts
+// that are no more than r apart. Each pair is weighted by d^-power, where
+// d is the distance between the points, so a power of zero gives binary
+// weights. When power is positive, coincident points are not neighbors.
+//
+// DistanceBand will panic if r is negative or if a coordinate of a point
+// in pts is not finite.
+func DistanceBand(pts []Point, r, power float64) Weights {
+	if r < 0 {
+		panic("pointpattern: negative distance band")
+	}
+	checkFinite(pts)
+	w := make(Weights, len(pts))
+	grid.New(pts, r).Pairs(r, func(i, j int, d float64) {
+		if power > 0 && d == 0 {
+			return
+		}
+		wt := math.Pow(d, -power)
+		w[i] = append(w[i], Neighbor{Index: j, Weight: wt})
+		w[j] = append(w[j], Neighbor{Index: i, Weight: wt})
+	})
+	return w
+}
+
+// RowStandardize scales the weights of each point so that they sum
+// to one. Points without neighbors are left unaltered.
+func (w Weights) RowStandardize() {
+	for _, row := range w {
+		var sum float64
+		for _, n := range row {
+			sum += n.Weight
+		}
+		if sum == 0 {
+			continue
+		}
+		for k := range row {
+			row[k].Weight /= sum
+		}
+	}
+}
+
+// Autocorrelation is a global spatial autocorrelation statistic with its
+// moments under the null hypothesis of no spatial autocorrelation.
+type Autocorrelation struct {
+	// Statistic is the value of the statistic.
+	Statistic float64
+
+	// Expected and Variance are the mean and variance of
+	// the statistic under the assumption that the values
+	// are independent normal variates.
+	Expected float64
+	Variance float64
+
+	// Z is the standard score of Statistic.
+	Z float64
+}
+
+// Moran returns Moran's I for the values x at points linked by the spatial
+// weights w. Positive values of I indicate that similar values cluster
+// together and negative values indicate that neighboring values tend to
+// differ.
+//
+// Moran will panic if x and w have different lengths.
+//
+// References:
+//   - Moran, P. A. P. (1950). Notes on continuous stochastic phenomena.
+//     Biometrika, 37(1/2), 17-23.
+//   - Cliff, A. D. and Ord, J. K. (1981). Spatial Processes: Models and
+//     Applications. Pion.
+func Moran(x []float64, w Weights) Autocorrelation {
+	z, m2, s := deviations(x, w)
+	var sum float64
+	for i, row := range w {
+		for _, nb := range row {
+			sum += nb.Weight * z[i] * z[nb.Index]
+		}
+	}
+	s0, s1, s2 := s.s0, s.s1, s.s2
+	n := float64(len(x))
+	i := n / s0 * sum / m2
+	e := -1 / (n - 1)
+	v := (n*n*s1-n*s2+3*s0*s0)/((n*n-1)*s0*s0) - e*e
+	return Autocorrelation{Statistic: i, Expected: e, Variance: v, Z: (i - e) / math.Sqrt(v)}
+}
+
+// Geary returns Geary's C for the values x at points linked by the spatial
+// weights w. Values of C below one indicate that similar values cluster
+// together and values above one indicate that neighboring values tend to
+// differ.
+//
+// Geary will panic if x and w have different lengths.
+//
+// References:
+//   - Geary, R. C. (1954). The contiguity ratio and statistical mapping.
+//     The Incorporated Statistician, 5(3), 115-145.
+//   - Cliff, A. D. and Ord, J. K. (1981). Spatial Processes: Models and
+//     Applications. Pion.
+func Geary(x []float64, w Weights) Autocorrelation {
+	_, m2, s := deviations(x, w)
+	var sum float64
+	for i, row := range w {
+		for _, nb := range row {
+			d := x[i] - x[nb.Index]
+			sum += nb.Weight * d * d
+		}
+	}
+	s0, s1, s2 := s.s0, s.s1, s.s2
+	n := float64(len(x))
+	c := (n - 1) * sum / (2 * s0 * m2)
+	v := ((2*s1+s2)*(n-1) - 4*s0*s0) / (2 * (n + 1) * s0 * s0)
+	return Autocorrelation{Statistic: c, Expected: 1, Variance: v, Z: (c - 1) / math.Sqrt(v)}
+}
+
+// weightSums holds the sums of a weights matrix used in the
+// moments of spatial autocorrelation statistics.
+type weightSums struct {
+	// s0 is the sum of the weights.
+	s0 float64
+	// s1 is half the sum of (w_ij + w_ji)^2.
+	s1 float64
+	// s2 is the sum over points of the squared
+	// sum of their row and column weights.
+	s2 float64
+}
+
+// deviations returns the deviations of x from its mean, the sum of their
+// squares and the sums of w needed for the moments of autocorrelation
+// statistics.
+func deviations(x []float64, w Weights) (z []float64, m2 float64, s weightSums) {
+	if len(x) != len(w) {
+		panic("pointpattern: length mismatch")
+	}
+	var mean float64
+	for _, v := range x {
+		mean += v
+	}
+	mean /= float64(len(x))
+	z = make([]float64, len(x))
+	for i, v := range x {
+		z[i] = v - mean
+		m2 += z[i] * z[i]
+	}
+
+	type pair struct{ i, j int }
+	ws := make(map[pair]float64)
+	row := make([]float64, len(w))
+	col := make([]float64, len(w))
+	for i, nbs := range w {
+		for _, nb := range nbs {
+			ws[pair{i, nb.Index}] += nb.Weight
+			row[i] += nb.Weight
+			col[nb.Index] += nb.Weight
+			s.s0 += nb.Weight
+		}
+	}
+	for p, v := range ws {
+		t, ok := ws[pair{p.j, p.i}]
+		t += v
+		s.s1 += t * t
+		if !ok {
+			// Account for the absent reverse pair.
+			s.s1 += t * t
+		}
+	}
+	s.s1 /= 2
+	for i := range row {
+		t := row[i] + col[i]
+		s.s2 += t * t
+	}
+	return z, m2, s
+}
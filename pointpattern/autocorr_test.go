@@ -0,0 +1,96 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pointpattern
+
+import (
+	"math"
+	"testing"
+)
+
+func gridPoints(rows, cols int) []Point {
+	var p []Point
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			p = append(p, Point{X: float64(c), Y: float64(r)})
+		}
+	}
+	return p
+}
+
+func TestDistanceBand(t *testing.T) {
+	pts := gridPoints(4, 5)
+	w := DistanceBand(pts, 1, 0)
+	for i, row := range w {
+		want := 4
+		x, y := pts[i].X, pts[i].Y
+		if x == 0 || x == 4 {
+			want--
+		}
+		if y == 0 || y == 3 {
+			want--
+		}
+		if len(row) != want {
+			t.Errorf("unexpected number of neighbors for %v: got:%d want:%d", pts[i], len(row), want)
+		}
+		for _, nb := range row {
+			if d := math.Hypot(pts[nb.Index].X-x, pts[nb.Index].Y-y); d != 1 || nb.Weight != 1 {
+				t.Errorf("unexpected neighbor of %v: %v at distance %v", pts[i], nb, d)
+			}
+		}
+	}
+
+	w = DistanceBand(pts, 1.5, 2)
+	w.RowStandardize()
+	for i, row := range w {
+		var sum float64
+		for _, nb := range row {
+			sum += nb.Weight
+		}
+		if math.Abs(sum-1) > 1e-14 {
+			t.Errorf("unexpected row sum for %v: got:%v want:1", pts[i], sum)
+		}
+	}
+}
+
+func TestAutocorrelation(t *testing.T) {
+	const tol = 1e-14
+
+	// A trend along a line of four points with
+	// binary weights between adjacent points.
+	pts := []Point{{X: 0}, {X: 1}, {X: 2}, {X: 3}}
+	x := []float64{1, 2, 3, 4}
+	w := DistanceBand(pts, 1, 0)
+	for _, test := range []struct {
+		name string
+		fn   func([]float64, Weights) Autocorrelation
+		want Autocorrelation
+	}{
+		{name: "Moran", fn: Moran, want: Autocorrelation{Statistic: 1.0 / 3, Expected: -1.0 / 3, Variance: 4.0 / 27}},
+		{name: "Geary", fn: Geary, want: Autocorrelation{Statistic: 0.3, Expected: 1, Variance: 2.0 / 15}},
+	} {
+		got := test.fn(x, w)
+		test.want.Z = (test.want.Statistic - test.want.Expected) / math.Sqrt(test.want.Variance)
+		if math.Abs(got.Statistic-test.want.Statistic) > tol ||
+			math.Abs(got.Expected-test.want.Expected) > tol ||
+			math.Abs(got.Variance-test.want.Variance) > tol ||
+			math.Abs(got.Z-test.want.Z) > tol {
+			t.Errorf("unexpected %s result: got:%+v want:%+v", test.name, got, test.want)
+		}
+	}
+
+	// A checkerboard is perfectly dispersed.
+	pts = gridPoints(6, 6)
+	x = make([]float64, len(pts))
+	for i, p := range pts {
+		x[i] = float64(int(p.X+p.Y) % 2)
+	}
+	w = DistanceBand(pts, 1, 0)
+	if got := Moran(x, w).Statistic; math.Abs(got+1) > tol {
+		t.Errorf("unexpected Moran's I for checkerboard: got:%v want:-1", got)
+	}
+	if got := Geary(x, w); got.Statistic <= 1 || got.Z <= 0 {
+		t.Errorf("unexpected Geary's C for checkerboard: got:%+v want statistic above 1", got)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pointpattern
+
+import (
+	"math"
+
+	"gonum.org/v1/exp/internal/grid"
+)
+
+// NearestDistances returns the distance from each point in pts to its
+// nearest other point. If pts holds fewer than two points, the distances
+// are +Inf.
+//
+// NearestDistances will panic if a coordinate of a point in pts is not
+// finite.
+func NearestDistances(pts []Point) []float64 {
+	checkFinite(pts)
+	g := grid.New(pts, 0)
+	d := make([]float64, len(pts))
+	for i, p := range pts {
+		_, d[i] = g.Nearest(p, i)
+	}
+	return d
+}
+
+// NearestHistogram returns the counts of nearest neighbor distances of pts
+// in the bins delimited by dividers. Bin i holds the distances d with
+// dividers[i] <= d < dividers[i+1]. Distances outside the range of the
+// dividers are not counted.
+//
+// NearestHistogram will panic if there are fewer than two dividers, if
+// the dividers are not strictly increasing or if a coordinate of a point
+// in pts is not finite.
+func NearestHistogram(pts []Point, dividers []float64) []float64 {
+	if len(dividers) < 2 {
+		panic("pointpattern: too few dividers")
+	}
+	for i := 1; i < len(dividers); i++ {
+		if !(dividers[i] > dividers[i-1]) {
+			panic("pointpattern: dividers not strictly increasing")
+		}
+	}
+	counts := make([]float64, len(dividers)-1)
+	for _, d := range NearestDistances(pts) {
+		if d < dividers[0] || d >= dividers[len(dividers)-1] {
+			continue
+		}
+		// Find the last divider not above d.
+		lo, hi := 0, len(dividers)-1
+		for hi-lo > 1 {
+			mid := (lo + hi) / 2
+			if dividers[mid] <= d {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		counts[lo]++
+	}
+	return counts
+}
+
+// ClarkEvans returns the Clark–Evans aggregation index of the points in pts
+// observed in window, the ratio of the mean nearest neighbor distance to
+// its expectation under complete spatial randomness. Values below one
+// indicate clustering and values above one indicate regularity, up to
+// about 2.15 for a hexagonal lattice. The standard score z of the mean
+// nearest neighbor distance and the two-sided p-value of the test of
+// complete spatial randomness are also returned.
+//
+// No edge correction is applied, so points near the window boundary bias
+// the index upward.
+//
+// ClarkEvans will panic if pts holds fewer than two points, if window
+// has no area or if a coordinate of a point in pts is not finite.
+//
+// References:
+//   - Clark, P. J. and Evans, F. C. (1954). Distance to nearest neighbor
+//     as a measure of spatial relationships in populations. Ecology,
+//     35(4), 445-453.
+func ClarkEvans(pts []Point, window Rect) (index, z, p float64) {
+	if len(pts) < 2 {
+		panic("pointpattern: too few points")
+	}
+	if !(window.Area() > 0) {
+		panic("pointpattern: empty window")
+	}
+	var mean float64
+	for _, d := range NearestDistances(pts) {
+		mean += d
+	}
+	n := float64(len(pts))
+	mean /= n
+	density := n / window.Area()
+	want := 0.5 / math.Sqrt(density)
+	se := 0.26136 / math.Sqrt(n*density)
+	z = (mean - want) / se
+	return mean / want, z, math.Erfc(math.Abs(z) / math.Sqrt2)
+}
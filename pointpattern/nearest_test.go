@@ -0,0 +1,101 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pointpattern
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestNearestDistances(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 10, 500} {
+		pts := uniformPoints(n, rand.NewSource(uint64(n)))
+		// Add a coincident point.
+		if n > 2 {
+			pts = append(pts, pts[n/2])
+		}
+		got := NearestDistances(pts)
+		for i, p := range pts {
+			want := math.Inf(1)
+			for j, q := range pts {
+				if j != i {
+					want = math.Min(want, math.Hypot(p.X-q.X, p.Y-q.Y))
+				}
+			}
+			if got[i] != want {
+				t.Errorf("unexpected nearest distance for point %d of %d: got:%v want:%v", i, len(pts), got[i], want)
+			}
+		}
+	}
+}
+
+func TestNonFinite(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		fn   func([]Point)
+	}{
+		{name: "NearestDistances", fn: func(p []Point) { NearestDistances(p) }},
+		{name: "DistanceBand", fn: func(p []Point) { DistanceBand(p, 0.5, 0) }},
+		{name: "RipleyK", fn: func(p []Point) { RipleyK(p, unit, []float64{0.1}, Isotropic) }},
+		{name: "PairCorrelation", fn: func(p []Point) { PairCorrelation(p, unit, []float64{0.1}, 0.02, Border) }},
+	} {
+		for _, bad := range []Point{{X: math.NaN()}, {Y: math.Inf(1)}, {X: math.Inf(-1)}} {
+			func() {
+				defer func() {
+					if r := recover(); r != "pointpattern: non-finite coordinate" {
+						t.Errorf("unexpected panic for %s with %v: got:%v want:%q", test.name, bad, r, "pointpattern: non-finite coordinate")
+					}
+				}()
+				test.fn([]Point{{X: 0.5, Y: 0.5}, bad, {X: 0.25, Y: 0.75}})
+			}()
+		}
+	}
+}
+
+func TestNearestHistogram(t *testing.T) {
+	pts := append(gridPoints(3, 3), Point{X: 10, Y: 10}, Point{X: 10, Y: 10.5})
+	got := NearestHistogram(pts, []float64{0, 0.5, 1, 2})
+	want := []float64{0, 2, 9}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("unexpected histogram: got:%v want:%v", got, want)
+			break
+		}
+	}
+}
+
+func TestClarkEvans(t *testing.T) {
+	// A square lattice has nearest neighbor
+	// distances twice their expectation.
+	pts := gridPoints(10, 10)
+	for i := range pts {
+		pts[i].X += 0.5
+		pts[i].Y += 0.5
+	}
+	window := Rect{Max: Point{X: 10, Y: 10}}
+	index, z, p := ClarkEvans(pts, window)
+	if math.Abs(index-2) > 1e-14 || z <= 0 || p > 1e-6 {
+		t.Errorf("unexpected result for lattice: got index:%v z:%v p:%v", index, z, p)
+	}
+
+	index, _, _ = ClarkEvans(uniformPoints(1000, rand.NewSource(1)), unit)
+	if math.Abs(index-1) > 0.05 {
+		t.Errorf("unexpected index for random points: got:%v want:1", index)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	pts = pts[:0]
+	for _, c := range uniformPoints(10, rand.NewSource(2)) {
+		for i := 0; i < 10; i++ {
+			pts = append(pts, Point{X: c.X + 0.01*rnd.Float64(), Y: c.Y + 0.01*rnd.Float64()})
+		}
+	}
+	index, z, p = ClarkEvans(pts, Rect{Min: Point{X: -1, Y: -1}, Max: Point{X: 2, Y: 2}})
+	if index >= 0.5 || z >= 0 || p > 1e-6 {
+		t.Errorf("unexpected result for clusters: got index:%v z:%v p:%v", index, z, p)
+	}
+}
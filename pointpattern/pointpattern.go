@@ -0,0 +1,30 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pointpattern provides exploratory statistics for planar
+// point patterns.
+//
+// References:
+//   - Illian, J., Penttinen, A., Stoyan, H. and Stoyan, D. (2008).
+//     Statistical Analysis and Modelling of Spatial Point Patterns.
+//     Wiley.
+package pointpattern
+
+import (
+	"math"
+
+	"gonum.org/v1/exp/spatial/r2"
+)
+
+// Point is a location in the plane.
+type Point = r2.Vec
+
+// checkFinite panics if a coordinate of a point in pts is not finite.
+func checkFinite(pts []Point) {
+	for _, p := range pts {
+		if math.IsInf(p.X, 0) || math.IsInf(p.Y, 0) || math.IsNaN(p.X) || math.IsNaN(p.Y) {
+			panic("pointpattern: non-finite coordinate")
+		}
+	}
+}
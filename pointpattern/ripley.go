@@ -0,0 +1,265 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pointpattern
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/exp/internal/grid"
+)
+
+// Rect is an axis-aligned rectangular observation window.
+type Rect struct {
+	Min, Max Point
+}
+
+// Area returns the area of the window.
+func (r Rect) Area() float64 {
+	return (r.Max.X - r.Min.X) * (r.Max.Y - r.Min.Y)
+}
+
+// contains returns whether p is within the closed window.
+func (r Rect) contains(p Point) bool {
+	return r.Min.X <= p.X && p.X <= r.Max.X && r.Min.Y <= p.Y && p.Y <= r.Max.Y
+}
+
+// border returns the distance from p to the boundary of the window.
+func (r Rect) border(p Point) float64 {
+	return math.Min(math.Min(p.X-r.Min.X, r.Max.X-p.X), math.Min(p.Y-r.Min.Y, r.Max.Y-p.Y))
+}
+
+// EdgeCorrection specifies how second order statistics account for
+// neighbors that lie outside the observation window.
+type EdgeCorrection int
+
+const (
+	// NoCorrection counts only the observed pairs
+	// and so underestimates at large distances.
+	NoCorrection EdgeCorrection = iota
+
+	// Border restricts estimates at distance r to points
+	// that are at least r from the window boundary.
+	Border
+
+	// Translation weights each pair by the inverse of the
+	// fraction of the window that remains in the window
+	// when translated by the pair's separation.
+	Translation
+
+	// Isotropic weights each pair by the inverse of the
+	// fraction of the circle centered on the first point
+	// and passing through the second that lies within
+	// the window.
+	Isotropic
+)
+
+// RipleyK returns estimates of Ripley's K function for the points in pts
+// observed in window, evaluated at each of the given radii. K(r) is the
+// expected number of further points within r of a typical point, divided
+// by the intensity of the pattern. For complete spatial randomness K(r)
+// is πr², larger values indicate clustering and smaller values indicate
+// regularity. With border correction, the estimate at a radius for which
+// no point is at least that far from the window boundary is zero.
+//
+// RipleyK will panic if pts holds fewer than two points, if a coordinate
+// of a point in pts is not finite, if window has no area or if any point
+// lies outside window.
+//
+// References:
+//   - Ripley, B. D. (1977). Modelling spatial patterns. Journal of the
+//     Royal Statistical Society B, 39(2), 172-212.
+func RipleyK(pts []Point, window Rect, radii []float64, corr EdgeCorrection) []float64 {
+	var reach float64
+	for _, r := range radii {
+		reach = math.Max(reach, r)
+	}
+	k := make([]float64, len(radii))
+	correctedPairs(pts, window, reach, corr, func(i int, d, e float64) {
+		for l, r := range radii {
+			if d <= r && (corr != Border || window.border(pts[i]) >= r) {
+				k[l] += e
+			}
+		}
+	})
+	for l, r := range radii {
+		c := centers(pts, window, r, corr)
+		if c == 0 {
+			k[l] = 0
+			continue
+		}
+		k[l] *= window.Area() / (float64(len(pts)) * c)
+	}
+	return k
+}
+
+// RipleyL returns estimates of Besag's L function, the square root of
+// Ripley's K function divided by π, for the points in pts observed in
+// window, evaluated at each of the given radii. For complete spatial
+// randomness L(r) is r.
+//
+// RipleyL will panic under the same conditions as RipleyK.
+//
+// References:
+//   - Besag, J. E. (1977). Comments on Ripley's paper. Journal of the
+//     Royal Statistical Society B, 39(2), 193-195.
+func RipleyL(pts []Point, window Rect, radii []float64, corr EdgeCorrection) []float64 {
+	l := RipleyK(pts, window, radii, corr)
+	for i, k := range l {
+		l[i] = math.Sqrt(k / math.Pi)
+	}
+	return l
+}
+
+// PairCorrelation returns kernel estimates of the pair correlation function
+// g for the points in pts observed in window, evaluated at each of the
+// given radii. g(r) is the derivative of Ripley's K function divided by
+// 2πr. For complete spatial randomness g(r) is one. Pair distances are
+// smoothed with an Epanechnikov kernel with the given bandwidth. The
+// estimate at a radius that is not positive is zero, as is the estimate
+// with border correction at a radius for which no point is at least that
+// far from the window boundary.
+//
+// PairCorrelation will panic if bandwidth is not positive or under the
+// same conditions as RipleyK.
+//
+// References:
+//   - Stoyan, D. and Stoyan, H. (1994). Fractals, Random Shapes and Point
+//     Fields. Wiley.
+func PairCorrelation(pts []Point, window Rect, radii []float64, bandwidth float64, corr EdgeCorrection) []float64 {
+	if bandwidth <= 0 {
+		panic("pointpattern: non-positive bandwidth")
+	}
+	var reach float64
+	for _, r := range radii {
+		reach = math.Max(reach, r+bandwidth)
+	}
+	g := make([]float64, len(radii))
+	correctedPairs(pts, window, reach, corr, func(i int, d, e float64) {
+		for l, r := range radii {
+			if corr == Border && window.border(pts[i]) < r {
+				continue
+			}
+			if u := (r - d) / bandwidth; math.Abs(u) < 1 {
+				g[l] += e * 0.75 * (1 - u*u) / bandwidth
+			}
+		}
+	})
+	for l, r := range radii {
+		c := centers(pts, window, r, corr)
+		if r <= 0 || c == 0 {
+			g[l] = 0
+			continue
+		}
+		g[l] *= window.Area() / (2 * math.Pi * r * float64(len(pts)) * c)
+	}
+	return g
+}
+
+// centers returns the number of points that act as centers of second
+// order estimates at distance r. With border correction these are the
+// points at least r from the window boundary, and otherwise all but one
+// point, giving the unbiased estimate of the squared intensity.
+func centers(pts []Point, window Rect, r float64, corr EdgeCorrection) float64 {
+	if corr != Border {
+		return float64(len(pts) - 1)
+	}
+	var n int
+	for _, p := range pts {
+		if window.border(p) >= r {
+			n++
+		}
+	}
+	return float64(n)
+}
+
+// correctedPairs calls fn for each ordered pair of distinct points i
+// and j in pts that are no more than reach apart, with their distance
+// d and edge correction weight e.
+func correctedPairs(pts []Point, window Rect, reach float64, corr EdgeCorrection, fn func(i int, d, e float64)) {
+	if len(pts) < 2 {
+		panic("pointpattern: too few points")
+	}
+	checkFinite(pts)
+	if !(window.Area() > 0) {
+		panic("pointpattern: empty window")
+	}
+	for _, p := range pts {
+		if !window.contains(p) {
+			panic("pointpattern: point outside window")
+		}
+	}
+	w, h := window.Max.X-window.Min.X, window.Max.Y-window.Min.Y
+	grid.New(pts, reach).Pairs(reach, func(i, j int, d float64) {
+		switch corr {
+		case NoCorrection, Border:
+			fn(i, d, 1)
+			fn(j, d, 1)
+		case Translation:
+			dx := math.Abs(pts[i].X - pts[j].X)
+			dy := math.Abs(pts[i].Y - pts[j].Y)
+			e := w * h / ((w - dx) * (h - dy))
+			if math.IsInf(e, 0) {
+				// The pair spans the window diagonally and
+				// can only be observed from a single place.
+				return
+			}
+			fn(i, d, e)
+			fn(j, d, e)
+		case Isotropic:
+			fn(i, d, isotropic(pts[i], window, d))
+			fn(j, d, isotropic(pts[j], window, d))
+		default:
+			panic("pointpattern: invalid edge correction")
+		}
+	})
+}
+
+// isotropic returns Ripley's isotropic edge correction weight for a circle
+// of radius d centered on p, the inverse of the fraction of the circle's
+// circumference within the window.
+func isotropic(p Point, window Rect, d float64) float64 {
+	if d == 0 {
+		return 1
+	}
+	// Collect the arcs of the circle outside each side of the window.
+	type arc struct{ lo, hi float64 }
+	var out []arc
+	for _, side := range []struct{ dist, dir float64 }{
+		{dist: window.Max.X - p.X, dir: 0},
+		{dist: window.Max.Y - p.Y, dir: math.Pi / 2},
+		{dist: p.X - window.Min.X, dir: math.Pi},
+		{dist: p.Y - window.Min.Y, dir: 3 * math.Pi / 2},
+	} {
+		if side.dist >= d {
+			continue
+		}
+		a := math.Acos(side.dist / d)
+		lo, hi := side.dir-a, side.dir+a
+		if lo < 0 {
+			out = append(out, arc{lo: lo + 2*math.Pi, hi: 2 * math.Pi})
+			lo = 0
+		}
+		out = append(out, arc{lo: lo, hi: hi})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].lo < out[j].lo })
+
+	// Measure the union of the arcs.
+	var outside, end float64
+	for _, a := range out {
+		if a.lo > end {
+			end = a.lo
+		}
+		if a.hi > end {
+			outside += a.hi - end
+			end = a.hi
+		}
+	}
+	inside := 1 - outside/(2*math.Pi)
+	if inside <= 0 {
+		return 0
+	}
+	return 1 / inside
+}
@@ -0,0 +1,125 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pointpattern
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+var unit = Rect{Max: Point{X: 1, Y: 1}}
+
+func uniformPoints(n int, src rand.Source) []Point {
+	rnd := rand.New(src)
+	p := make([]Point, n)
+	for i := range p {
+		p[i] = Point{X: rnd.Float64(), Y: rnd.Float64()}
+	}
+	return p
+}
+
+func TestIsotropic(t *testing.T) {
+	for _, test := range []struct {
+		p    Point
+		d    float64
+		want float64
+	}{
+		{p: Point{X: 0.5, Y: 0.5}, d: 0.2, want: 1},
+		{p: Point{X: 0.5, Y: 0}, d: 0.2, want: 2},
+		{p: Point{X: 0, Y: 0}, d: 0.2, want: 4},
+		{p: Point{X: 1, Y: 1}, d: 0.2, want: 4},
+		{p: Point{X: 0.1, Y: 0.5}, d: 0.2, want: 1 / (1 - 2*math.Acos(0.5)/(2*math.Pi))},
+		// Arcs beyond adjacent sides overlap near a corner.
+		{p: Point{X: 0.1, Y: 0.1}, d: 0.5, want: 4 / (1 + 4*math.Asin(0.2)/math.Pi)},
+	} {
+		got := isotropic(test.p, unit, test.d)
+		if math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected weight for %v at %v: got:%v want:%v", test.p, test.d, got, test.want)
+		}
+	}
+}
+
+func TestRipleyPoisson(t *testing.T) {
+	pts := uniformPoints(1000, rand.NewSource(1))
+	radii := []float64{0.05, 0.1, 0.15}
+	for _, corr := range []EdgeCorrection{Border, Translation, Isotropic} {
+		l := RipleyL(pts, unit, radii, corr)
+		for i, r := range radii {
+			if math.Abs(l[i]-r) > 0.01 {
+				t.Errorf("unexpected L(%v) for correction %d: got:%v want:%v", r, corr, l[i], r)
+			}
+		}
+		g := PairCorrelation(pts, unit, radii, 0.02, corr)
+		for i, r := range radii {
+			if math.Abs(g[i]-1) > 0.15 {
+				t.Errorf("unexpected g(%v) for correction %d: got:%v want:1", r, corr, g[i])
+			}
+		}
+	}
+
+	// Without correction, pairs lost across the
+	// boundary bias K below its expectation.
+	k := RipleyK(pts, unit, radii, NoCorrection)
+	for i, r := range radii {
+		if want := math.Pi * r * r; k[i] >= want {
+			t.Errorf("uncorrected K(%v) not below expectation: got:%v want below:%v", r, k[i], want)
+		}
+	}
+}
+
+func TestRipleyClustered(t *testing.T) {
+	// Tight clusters about random parents.
+	rnd := rand.New(rand.NewSource(1))
+	var pts []Point
+	for _, c := range uniformPoints(20, rand.NewSource(2)) {
+		for i := 0; i < 20; i++ {
+			p := Point{X: c.X + 0.02*rnd.NormFloat64(), Y: c.Y + 0.02*rnd.NormFloat64()}
+			if unit.contains(p) {
+				pts = append(pts, p)
+			}
+		}
+	}
+	const r = 0.05
+	if got := RipleyL(pts, unit, []float64{r}, Isotropic)[0]; got <= 2*r {
+		t.Errorf("clustering not detected: got:%v want above:%v", got, 2*r)
+	}
+	if got := PairCorrelation(pts, unit, []float64{r}, 0.02, Translation)[0]; got <= 2 {
+		t.Errorf("clustering not detected: got:%v want above:2", got)
+	}
+}
+
+func TestRipleyUndefined(t *testing.T) {
+	pts := uniformPoints(100, rand.NewSource(1))
+	// No point is 0.6 from the boundary of the unit square.
+	radii := []float64{0, 0.6}
+	for _, test := range []struct {
+		corr EdgeCorrection
+		k, g []float64
+	}{
+		{corr: Border, k: []float64{0, 0}, g: []float64{0, 0}},
+		{corr: Translation, g: []float64{0}},
+		{corr: Isotropic, g: []float64{0}},
+	} {
+		k := RipleyK(pts, unit, radii, test.corr)
+		for i, want := range test.k {
+			if k[i] != want {
+				t.Errorf("unexpected K(%v) for correction %d: got:%v want:%v", radii[i], test.corr, k[i], want)
+			}
+		}
+		g := PairCorrelation(pts, unit, radii, 0.02, test.corr)
+		for i, want := range test.g {
+			if g[i] != want {
+				t.Errorf("unexpected g(%v) for correction %d: got:%v want:%v", radii[i], test.corr, g[i], want)
+			}
+		}
+		for i := range radii {
+			if math.IsNaN(k[i]) || math.IsInf(k[i], 0) || math.IsNaN(g[i]) || math.IsInf(g[i], 0) {
+				t.Errorf("non-finite estimate at %v for correction %d: K:%v g:%v", radii[i], test.corr, k[i], g[i])
+			}
+		}
+	}
+}
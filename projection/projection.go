@@ -0,0 +1,84 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package projection provides linear projections of high-dimensional
+// observations to low-dimensional coordinates, for example to give initial
+// two- or three-dimensional positions in embedding workflows.
+package projection
+
+import (
+	"math"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+// PCA returns the projection of the rows of x onto the first k principal
+// components of x, as an n×k matrix where n is the number of rows of x.
+// The projected coordinates are centered on the origin and have
+// decreasing variance along successive columns. PCA returns whether the
+// analysis was successful.
+//
+// PCA will panic if k is not positive or is greater than the smaller of the
+// dimensions of x.
+func PCA(x mat.Matrix, k int) (proj *mat.Dense, ok bool) {
+	n, d := x.Dims()
+	if k <= 0 || k > n || k > d {
+		panic("projection: invalid number of dimensions")
+	}
+	var pc stat.PC
+	ok = pc.PrincipalComponents(x, nil)
+	if !ok {
+		return nil, false
+	}
+	vecs := pc.VectorsTo(nil)
+
+	c := mat.DenseCopyOf(x)
+	for j := 0; j < d; j++ {
+		mean := stat.Mean(mat.Col(nil, j, c), nil)
+		for i := 0; i < n; i++ {
+			c.Set(i, j, c.At(i, j)-mean)
+		}
+	}
+	proj = mat.NewDense(n, k, nil)
+	proj.Mul(c, vecs.Slice(0, d, 0, k))
+	return proj, true
+}
+
+// Random returns the projection of the rows of x onto k random directions,
+// as an n×k matrix where n is the number of rows of x. The elements of the
+// projection matrix are independent normal variates scaled by 1/sqrt(k),
+// so squared distances between rows are preserved in expectation and,
+// by the Johnson-Lindenstrauss lemma, approximately preserved when k is
+// large. Random numbers are drawn from src, or the global source if src is
+// nil.
+//
+// Random will panic if k is not positive.
+//
+// References:
+//   - Dasgupta, S. and Gupta, A. (2003). An elementary proof of a theorem
+//     of Johnson and Lindenstrauss. Random Structures & Algorithms, 22(1),
+//     60-65.
+func Random(x mat.Matrix, k int, src rand.Source) *mat.Dense {
+	if k <= 0 {
+		panic("projection: invalid number of dimensions")
+	}
+	norm := rand.NormFloat64
+	if src != nil {
+		norm = rand.New(src).NormFloat64
+	}
+	n, d := x.Dims()
+	r := mat.NewDense(d, k, nil)
+	scale := 1 / math.Sqrt(float64(k))
+	for i := 0; i < d; i++ {
+		for j := 0; j < k; j++ {
+			r.Set(i, j, scale*norm())
+		}
+	}
+	proj := mat.NewDense(n, k, nil)
+	proj.Mul(x, r)
+	return proj
+}
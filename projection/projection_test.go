@@ -0,0 +1,106 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package projection
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func rowDist(m mat.Matrix, i, j int) float64 {
+	a := mat.Row(nil, i, m)
+	b := mat.Row(nil, j, m)
+	return floats.Distance(a, b, 2)
+}
+
+func TestPCA(t *testing.T) {
+	// Points on a plane embedded in five dimensions
+	// are projected isometrically onto two.
+	rnd := rand.New(rand.NewSource(1))
+	basis := mat.NewDense(2, 5, []float64{
+		1, 2, 0, -1, 3,
+		0, 1, 1, 2, -1,
+	})
+	var q mat.QR
+	q.Factorize(basis.T())
+	var orth mat.Dense
+	q.QTo(&orth)
+	const n = 20
+	coef := mat.NewDense(n, 2, nil)
+	for i := 0; i < n; i++ {
+		coef.Set(i, 0, 10*rnd.NormFloat64())
+		coef.Set(i, 1, rnd.NormFloat64())
+	}
+	var x mat.Dense
+	x.Mul(coef, orth.Slice(0, 5, 0, 2).T())
+	for i := 0; i < n; i++ {
+		for j := 0; j < 5; j++ {
+			x.Set(i, j, x.At(i, j)+float64(j))
+		}
+	}
+
+	proj, ok := PCA(&x, 2)
+	if !ok {
+		t.Fatal("unexpected PCA failure")
+	}
+	if r, c := proj.Dims(); r != n || c != 2 {
+		t.Fatalf("unexpected projection size: got:%d×%d want:%d×2", r, c, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			if got, want := rowDist(proj, i, j), rowDist(&x, i, j); math.Abs(got-want) > 1e-10 {
+				t.Errorf("unexpected distance between %d and %d: got:%v want:%v", i, j, got, want)
+			}
+		}
+	}
+	var v [2]float64
+	for j := range v {
+		for i := 0; i < n; i++ {
+			v[j] += proj.At(i, j) * proj.At(i, j)
+		}
+	}
+	if v[0] < v[1] {
+		t.Errorf("components not in order of decreasing variance: %v", v)
+	}
+
+	first, _ := PCA(&x, 1)
+	for i := 0; i < n; i++ {
+		if first.At(i, 0) != proj.At(i, 0) {
+			t.Errorf("unexpected first component for row %d: got:%v want:%v", i, first.At(i, 0), proj.At(i, 0))
+		}
+	}
+}
+
+func TestRandom(t *testing.T) {
+	const (
+		n = 10
+		d = 1000
+		k = 400
+	)
+	rnd := rand.New(rand.NewSource(1))
+	x := mat.NewDense(n, d, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < d; j++ {
+			x.Set(i, j, rnd.NormFloat64())
+		}
+	}
+	proj := Random(x, k, rand.NewSource(2))
+	if r, c := proj.Dims(); r != n || c != k {
+		t.Fatalf("unexpected projection size: got:%d×%d want:%d×%d", r, c, n, k)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			ratio := rowDist(proj, i, j) / rowDist(x, i, j)
+			if math.Abs(ratio-1) > 0.2 {
+				t.Errorf("distance between %d and %d distorted by ratio %v", i, j, ratio)
+			}
+		}
+	}
+}
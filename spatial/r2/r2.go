@@ -0,0 +1,12 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package r2 provides the two-dimensional vector type shared by the
+// packages that work with points in the plane.
+package r2
+
+// Vec is a two-dimensional vector.
+type Vec struct {
+	X, Y float64
+}
@@ -0,0 +1,142 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package stipple generates point sets whose density follows the
+// tone of an image.
+//
+// Points are initially placed by importance sampling the image and are
+// then moved toward the density-weighted centroids of their Voronoi
+// regions by Lloyd relaxation, giving the evenly spaced appearance of
+// hand stippling.
+//
+// References:
+//   - Secord, A. (2002). Weighted Voronoi stippling. In Proceedings of the
+//     2nd International Symposium on Non-photorealistic Animation and
+//     Rendering (pp. 37-43). ACM.
+package stipple
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/exp/internal/grid"
+	"gonum.org/v1/exp/spatial/r2"
+)
+
+// Point is a location in image coordinates. Pixel (x, y) covers
+// the region [x, x+1)×[y, y+1).
+type Point = r2.Vec
+
+// Settings holds parameters for stippling.
+type Settings struct {
+	// Iterations is the number of Lloyd relaxation
+	// steps. If Iterations is zero, points are
+	// placed by importance sampling alone.
+	Iterations int
+
+	// Invert specifies that light pixels are dense.
+	// By default dark pixels are dense.
+	Invert bool
+}
+
+// Stipple returns n points distributed over img with density proportional
+// to the darkness of the image, or lightness if settings.Invert is true.
+// If settings is nil, 20 relaxation iterations are performed. Random
+// numbers are drawn from src, or the global source if src is nil.
+//
+// The density of each pixel is weighted by its opacity. If no pixel has
+// a non-zero density, as for an all-white image, or an all-black image
+// when settings.Invert is true, or a fully transparent image, Stipple
+// returns nil.
+//
+// Stipple will panic if n is negative.
+func Stipple(img image.Image, n int, settings *Settings, src rand.Source) []Point {
+	if n < 0 {
+		panic("stipple: negative number of points")
+	}
+	if settings == nil {
+		settings = &Settings{Iterations: 20}
+	}
+	rnd := rand.Float64
+	if src != nil {
+		rnd = rand.New(src).Float64
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	density := make([]float64, w*h)
+	cdf := make([]float64, w*h)
+	var sum float64
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			// Luminance is alpha-premultiplied, so density
+			// is weighted by the opacity of the pixel.
+			c := img.At(b.Min.X+x, b.Min.Y+y)
+			_, _, _, a := c.RGBA()
+			g := float64(color.Gray16Model.Convert(c).(color.Gray16).Y) / 0xffff
+			if !settings.Invert {
+				g = math.Max(0, float64(a)/0xffff-g)
+			}
+			density[y*w+x] = g
+			sum += g
+			cdf[y*w+x] = sum
+		}
+	}
+	if n == 0 || sum == 0 {
+		return nil
+	}
+
+	pts := make([]Point, n)
+	for i := range pts {
+		j := sort.SearchFloat64s(cdf, rnd()*sum)
+		if j == len(cdf) {
+			j--
+		}
+		// Skip zero density pixels that share a
+		// cumulative value with the next dense pixel.
+		for density[j] == 0 {
+			j++
+		}
+		pts[i] = Point{
+			X: float64(b.Min.X+j%w) + rnd(),
+			Y: float64(b.Min.Y+j/w) + rnd(),
+		}
+	}
+
+	type centroid struct {
+		x, y, w float64
+	}
+	acc := make([]centroid, n)
+	for it := 0; it < settings.Iterations; it++ {
+		g := grid.New(pts, 0)
+		for i := range acc {
+			acc[i] = centroid{}
+		}
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				d := density[y*w+x]
+				if d == 0 {
+					continue
+				}
+				px := float64(b.Min.X+x) + 0.5
+				py := float64(b.Min.Y+y) + 0.5
+				k, _ := g.Nearest(Point{X: px, Y: py}, -1)
+				c := &acc[k]
+				c.x += d * px
+				c.y += d * py
+				c.w += d
+			}
+		}
+		for i, c := range acc {
+			if c.w != 0 {
+				pts[i] = Point{X: c.x / c.w, Y: c.y / c.w}
+			}
+		}
+	}
+	return pts
+}
@@ -0,0 +1,100 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stipple
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+// halves returns an image with a black left half and a white right half.
+func halves(r image.Rectangle) *image.Gray {
+	img := image.NewGray(r)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if x >= (r.Min.X+r.Max.X)/2 {
+				img.SetGray(x, y, color.Gray{Y: 0xff})
+			}
+		}
+	}
+	return img
+}
+
+func TestStippleHalves(t *testing.T) {
+	r := image.Rect(10, -5, 50, 35)
+	for _, test := range []struct {
+		settings *Settings
+		dark     bool
+	}{
+		{settings: nil, dark: true},
+		{settings: &Settings{Iterations: 0}, dark: true},
+		{settings: &Settings{Iterations: 5, Invert: true}, dark: false},
+	} {
+		const n = 200
+		pts := Stipple(halves(r), n, test.settings, rand.NewSource(1))
+		if len(pts) != n {
+			t.Fatalf("unexpected number of points: got:%d want:%d", len(pts), n)
+		}
+		for _, p := range pts {
+			if p.Y < float64(r.Min.Y) || p.Y >= float64(r.Max.Y) {
+				t.Errorf("point outside image: %v", p)
+			}
+			left := p.X >= float64(r.Min.X) && p.X < 30
+			right := p.X >= 30 && p.X < float64(r.Max.X)
+			if test.dark && !left || !test.dark && !right {
+				t.Errorf("point outside dense region for settings %+v: %v", test.settings, p)
+			}
+		}
+	}
+}
+
+func TestStippleRelaxation(t *testing.T) {
+	// Relaxation over a uniform image should space points more evenly
+	// than random sampling, increasing the minimum separation.
+	img := image.NewGray(image.Rect(0, 0, 64, 64))
+	const n = 64
+	sampled := Stipple(img, n, &Settings{}, rand.NewSource(1))
+	relaxed := Stipple(img, n, &Settings{Iterations: 30}, rand.NewSource(1))
+	if minSep(relaxed) <= 2*minSep(sampled) {
+		t.Errorf("relaxation did not spread points: sampled:%v relaxed:%v", minSep(sampled), minSep(relaxed))
+	}
+}
+
+func minSep(pts []Point) float64 {
+	min := math.Inf(1)
+	for i, p := range pts {
+		for _, q := range pts[:i] {
+			min = math.Min(min, math.Hypot(p.X-q.X, p.Y-q.Y))
+		}
+	}
+	return min
+}
+
+func TestStippleBlank(t *testing.T) {
+	white := image.NewGray(image.Rect(0, 0, 8, 8))
+	for i := range white.Pix {
+		white.Pix[i] = 0xff
+	}
+	black := image.NewGray(image.Rect(0, 0, 8, 8))
+	transparent := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for _, test := range []struct {
+		name     string
+		img      image.Image
+		settings *Settings
+	}{
+		{name: "white", img: white},
+		{name: "inverted black", img: black, settings: &Settings{Invert: true}},
+		{name: "transparent", img: transparent},
+		{name: "inverted transparent", img: transparent, settings: &Settings{Invert: true}},
+	} {
+		if pts := Stipple(test.img, 10, test.settings, rand.NewSource(1)); pts != nil {
+			t.Errorf("unexpected points for %s image: %v", test.name, pts)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package termplot
+
+import "strings"
+
+// brailleBlank is the empty braille pattern. Dots are added by setting
+// bits in its low byte.
+const brailleBlank = '⠀'
+
+// brailleDots holds the bit for each dot of a braille cell indexed
+// by the dot's column and row within the cell.
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// Canvas is a grid of braille characters addressable by dot.
+// Each character cell holds two columns and four rows of dots.
+type Canvas struct {
+	cols, rows int
+	cells      []rune
+}
+
+// NewCanvas returns a blank canvas of cols×rows characters.
+// NewCanvas will panic if cols or rows is not positive.
+func NewCanvas(cols, rows int) *Canvas {
+	if cols <= 0 || rows <= 0 {
+		panic("termplot: invalid size")
+	}
+	cells := make([]rune, cols*rows)
+	for i := range cells {
+		cells[i] = brailleBlank
+	}
+	return &Canvas{cols: cols, rows: rows, cells: cells}
+}
+
+// Dots returns the number of dot columns and rows in the canvas.
+func (c *Canvas) Dots() (w, h int) {
+	return 2 * c.cols, 4 * c.rows
+}
+
+// Set sets the dot at (x, y), where (0, 0) is the top left dot.
+// Dots outside the canvas are ignored.
+func (c *Canvas) Set(x, y int) {
+	if x < 0 || y < 0 || x >= 2*c.cols || y >= 4*c.rows {
+		return
+	}
+	c.cells[(y/4)*c.cols+x/2] |= brailleDots[x%2][y%4]
+}
+
+// Clear removes all dots from the canvas.
+func (c *Canvas) Clear() {
+	for i := range c.cells {
+		c.cells[i] = brailleBlank
+	}
+}
+
+// String returns the canvas as rows of braille characters,
+// each terminated by a newline.
+func (c *Canvas) String() string {
+	var sb strings.Builder
+	for r := 0; r < c.rows; r++ {
+		sb.WriteString(string(c.cells[r*c.cols : (r+1)*c.cols]))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,120 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package termplot renders point sets as text for display in a terminal.
+//
+// Points can be drawn with Unicode braille patterns, giving a resolution
+// of two by four dots per character cell, or with ASCII characters that
+// indicate the number of points falling in each cell.
+package termplot
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// Size returns the terminal size in character cells as reported by
+// the COLUMNS and LINES environment variables. If either is unset or
+// invalid, the corresponding dimension of an 80×24 terminal is returned.
+func Size() (cols, rows int) {
+	cols, rows = 80, 24
+	if c, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && c > 0 {
+		cols = c
+	}
+	if r, err := strconv.Atoi(os.Getenv("LINES")); err == nil && r > 0 {
+		rows = r
+	}
+	return cols, rows
+}
+
+// Braille returns a rendering of the points in xys as a grid of
+// cols×rows braille characters, scaled to fill the grid. Rows are
+// separated by newlines with y increasing upward.
+func Braille(xys plotter.XYer, cols, rows int) string {
+	c := NewCanvas(cols, rows)
+	w, h := c.Dots()
+	scale(xys, w, h, c.Set)
+	return c.String()
+}
+
+// asciiRamp holds characters of increasing visual density.
+const asciiRamp = " .:-=+*#%@"
+
+// ASCII returns a rendering of the points in xys as a grid of cols×rows
+// ASCII characters, scaled to fill the grid. The character in each cell
+// indicates the number of points in the cell relative to the most
+// populated cell. Rows are separated by newlines with y increasing upward.
+func ASCII(xys plotter.XYer, cols, rows int) string {
+	if cols <= 0 || rows <= 0 {
+		panic("termplot: invalid size")
+	}
+	counts := make([]int, cols*rows)
+	max := 1
+	scale(xys, cols, rows, func(x, y int) {
+		counts[y*cols+x]++
+		if counts[y*cols+x] > max {
+			max = counts[y*cols+x]
+		}
+	})
+	var sb strings.Builder
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			// Round up so that occupied cells are always visible.
+			n := counts[y*cols+x]
+			sb.WriteByte(asciiRamp[(n*(len(asciiRamp)-1)+max-1)/max])
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// scale maps the finite points in xys onto a w×h grid with the origin
+// at the top left, calling fn with the grid coordinates of each point.
+func scale(xys plotter.XYer, w, h int, fn func(x, y int)) {
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for i := 0; i < xys.Len(); i++ {
+		x, y := xys.XY(i)
+		if !finite(x) || !finite(y) {
+			continue
+		}
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+		minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+	}
+	for i := 0; i < xys.Len(); i++ {
+		x, y := xys.XY(i)
+		if !finite(x) || !finite(y) {
+			continue
+		}
+		fn(cell(x, minX, maxX, w), h-1-cell(y, minY, maxY, h))
+	}
+}
+
+// cell returns the index of the cell of n that v falls in when
+// [min, max] is divided evenly. If min == max, the middle cell
+// is returned.
+func cell(v, min, max float64, n int) int {
+	if min == max {
+		return n / 2
+	}
+	// Halve the values so that the span of
+	// finite values cannot overflow.
+	t := (v/2 - min/2) / (max/2 - min/2)
+	i := int(t * float64(n))
+	switch {
+	case i < 0:
+		i = 0
+	case i >= n:
+		i = n - 1
+	}
+	return i
+}
+
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
@@ -0,0 +1,125 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package termplot
+
+import (
+	"math"
+	"os"
+	"testing"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+func TestCanvas(t *testing.T) {
+	c := NewCanvas(2, 1)
+	w, h := c.Dots()
+	if w != 4 || h != 4 {
+		t.Fatalf("unexpected dots: got:%d×%d want:4×4", w, h)
+	}
+	c.Set(0, 0)
+	c.Set(1, 3)
+	c.Set(3, 1)
+	c.Set(4, 0) // Outside.
+	c.Set(-1, 0)
+	want := "⢁⠐\n"
+	if got := c.String(); got != want {
+		t.Errorf("unexpected canvas:\ngot: %q\nwant:%q", got, want)
+	}
+	c.Clear()
+	want = "⠀⠀\n"
+	if got := c.String(); got != want {
+		t.Errorf("unexpected cleared canvas:\ngot: %q\nwant:%q", got, want)
+	}
+}
+
+func TestBraille(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		xys        plotter.XYs
+		cols, rows int
+		want       string
+	}{
+		{
+			name: "corners",
+			xys:  plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}, {X: 1, Y: 0}},
+			cols: 2, rows: 2,
+			want: "⠁⠈\n⡀⢀\n",
+		},
+		{
+			name: "diagonal",
+			xys:  plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 3}},
+			cols: 2, rows: 1,
+			want: "⡠⠊\n",
+		},
+		{
+			name: "single",
+			xys:  plotter.XYs{{X: 5, Y: 5}, {X: math.NaN(), Y: 0}},
+			cols: 1, rows: 1,
+			want: "⠐\n",
+		},
+		{
+			name: "extreme",
+			xys:  plotter.XYs{{X: -1e308, Y: -1e308}, {X: 0, Y: 0}, {X: 1e308, Y: 1e308}},
+			cols: 1, rows: 1,
+			want: "⡘\n",
+		},
+		{
+			name: "empty",
+			cols: 1, rows: 2,
+			want: "⠀\n⠀\n",
+		},
+	} {
+		got := Braille(test.xys, test.cols, test.rows)
+		if got != test.want {
+			t.Errorf("unexpected rendering for %s:\ngot: %q\nwant:%q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestASCII(t *testing.T) {
+	xys := plotter.XYs{{X: 0, Y: 0}, {X: 0, Y: 0.1}, {X: 0.1, Y: 0}, {X: 0.1, Y: 0.1}, {X: 1, Y: 1}, {X: 1, Y: 0}}
+	want := "  -\n@ -\n"
+	if got := ASCII(xys, 3, 2); got != want {
+		t.Errorf("unexpected rendering:\ngot: %q\nwant:%q", got, want)
+	}
+	want = "@@@\n"
+	if got := ASCII(plotter.XYs{{X: -math.MaxFloat64}, {X: 0}, {X: math.MaxFloat64}}, 3, 1); got != want {
+		t.Errorf("unexpected extreme rendering:\ngot: %q\nwant:%q", got, want)
+	}
+	want = "   \n   \n"
+	if got := ASCII(plotter.XYs{}, 3, 2); got != want {
+		t.Errorf("unexpected empty rendering:\ngot: %q\nwant:%q", got, want)
+	}
+}
+
+func TestSize(t *testing.T) {
+	for _, key := range []string{"COLUMNS", "LINES"} {
+		old, ok := os.LookupEnv(key)
+		defer func(key string) {
+			if ok {
+				os.Setenv(key, old)
+			} else {
+				os.Unsetenv(key)
+			}
+		}(key)
+	}
+
+	for _, test := range []struct {
+		columns, lines string
+		cols, rows     int
+	}{
+		{columns: "", lines: "", cols: 80, rows: 24},
+		{columns: "120", lines: "40", cols: 120, rows: 40},
+		{columns: "x", lines: "-1", cols: 80, rows: 24},
+	} {
+		os.Setenv("COLUMNS", test.columns)
+		os.Setenv("LINES", test.lines)
+		cols, rows := Size()
+		if cols != test.cols || rows != test.rows {
+			t.Errorf("unexpected size for COLUMNS=%q LINES=%q: got:%d×%d want:%d×%d",
+				test.columns, test.lines, cols, rows, test.cols, test.rows)
+		}
+	}
+}